@@ -0,0 +1,217 @@
+package twitter
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy is what a Broker does with a message when a Subscription's
+// buffer is full.
+type OverflowPolicy int
+
+// Overflow policies for SubscriptionParams.
+const (
+	// OverflowBlock waits for the subscriber to receive, which holds up the
+	// Broker and so every other subscriber.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the message.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest buffered message to make room.
+	OverflowDropOldest
+	// OverflowUnsubscribe drops the message and unsubscribes the subscriber.
+	OverflowUnsubscribe
+)
+
+// SubscriptionParams are parameters for Broker.Subscribe.
+type SubscriptionParams struct {
+	// Buffer is the number of messages buffered for the subscriber.
+	// OverflowDropOldest buffers at least 1.
+	Buffer int
+	// Overflow is the OverflowPolicy when the buffer is full.
+	Overflow OverflowPolicy
+	// Filter, if not nil, selects the messages sent to the subscriber.
+	Filter func(message interface{}) bool
+}
+
+// Broker is a Demux which broadcasts messages to many subscribers, so that a
+// single Stream can feed several consumers. Subscribers may subscribe and
+// unsubscribe at any time without affecting the Stream.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewBroker returns a new Broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe adds a subscriber which receives messages handled from now on.
+// If the Broker is closed, the Subscription's Messages channel is closed.
+func (b *Broker) Subscribe(params *SubscriptionParams) *Subscription {
+	if params == nil {
+		params = &SubscriptionParams{}
+	}
+	buffer := params.Buffer
+	if buffer < 1 && params.Overflow == OverflowDropOldest {
+		buffer = 1
+	}
+	messages := make(chan interface{}, buffer)
+	s := &Subscription{
+		Messages: messages,
+		broker:   b,
+		messages: messages,
+		filter:   params.Filter,
+		overflow: params.Overflow,
+		done:     make(chan struct{}),
+	}
+	b.mu.Lock()
+	closed := b.closed
+	if !closed {
+		b.subs[s] = struct{}{}
+	}
+	b.mu.Unlock()
+	if closed {
+		s.Unsubscribe()
+	}
+	return s
+}
+
+// Handle sends the message to each subscriber.
+func (b *Broker) Handle(message interface{}) {
+	b.mu.Lock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.Unlock()
+	for _, s := range subs {
+		s.send(message)
+	}
+}
+
+// HandleChan sends each message from the channel to each subscriber. When the
+// channel closes, the Broker is closed.
+func (b *Broker) HandleChan(messages <-chan interface{}) {
+	for message := range messages {
+		b.Handle(message)
+	}
+	b.Close()
+}
+
+// Close unsubscribes every subscriber, closing their Messages channels.
+// Later subscriptions are closed immediately.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[*Subscription]struct{})
+	b.mu.Unlock()
+	for s := range subs {
+		s.Unsubscribe()
+	}
+}
+
+// remove removes a subscriber from the Broker.
+func (b *Broker) remove(s *Subscription) {
+	b.mu.Lock()
+	delete(b.subs, s)
+	b.mu.Unlock()
+}
+
+// Subscription is a subscriber to a Broker.
+type Subscription struct {
+	// dropped is first to be 64-bit aligned for atomic operations
+	dropped int64
+	// Messages receives the messages selected by the Filter. It is closed
+	// when unsubscribed.
+	Messages <-chan interface{}
+	broker   *Broker
+	messages chan interface{}
+	filter   func(message interface{}) bool
+	overflow OverflowPolicy
+	done     chan struct{}
+	once     sync.Once
+	// mu guards sending on and closing the messages channel
+	mu     sync.Mutex
+	closed bool
+}
+
+// Unsubscribe stops sending messages to the subscriber and closes the
+// Messages channel. Buffered messages may still be received.
+func (s *Subscription) Unsubscribe() {
+	s.broker.remove(s)
+	// unblock a Broker waiting to send before closing the channel
+	s.once.Do(func() {
+		close(s.done)
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.close()
+}
+
+// Dropped returns the number of messages dropped because the buffer was full.
+func (s *Subscription) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// send sends a message to the subscriber according to its Filter and
+// OverflowPolicy.
+func (s *Subscription) send(message interface{}) {
+	if s.filter != nil && !s.filter(message) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	switch s.overflow {
+	case OverflowDropNewest:
+		select {
+		case s.messages <- message:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.messages <- message:
+				return
+			default:
+			}
+			select {
+			case <-s.messages:
+				atomic.AddInt64(&s.dropped, 1)
+			default:
+			}
+		}
+	case OverflowUnsubscribe:
+		select {
+		case s.messages <- message:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+			s.broker.remove(s)
+			s.once.Do(func() {
+				close(s.done)
+			})
+			s.close()
+		}
+	default:
+		select {
+		case s.messages <- message:
+		case <-s.done:
+		}
+	}
+}
+
+// close closes the messages channel once. Callers must hold mu.
+func (s *Subscription) close() {
+	if !s.closed {
+		s.closed = true
+		close(s.messages)
+	}
+}
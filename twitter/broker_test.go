@@ -0,0 +1,94 @@
+package twitter
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveAll returns the messages received from the channel until it closes.
+func receiveAll(ch <-chan interface{}) []interface{} {
+	var messages []interface{}
+	for message := range ch {
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func TestBroker_HandleChan(t *testing.T) {
+	broker := NewBroker()
+	all := broker.Subscribe(nil)
+	tweets := broker.Subscribe(&SubscriptionParams{
+		Buffer: 10,
+		Filter: func(message interface{}) bool {
+			_, ok := message.(*Tweet)
+			return ok
+		},
+	})
+	messages := make(chan interface{})
+	go broker.HandleChan(messages)
+
+	var wg sync.WaitGroup
+	var allReceived, tweetsReceived []interface{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		allReceived = receiveAll(all.Messages)
+	}()
+	go func() {
+		defer wg.Done()
+		tweetsReceived = receiveAll(tweets.Messages)
+	}()
+	tweet, event := &Tweet{ID: 1}, &Event{Event: FollowEvent}
+	messages <- tweet
+	messages <- event
+	close(messages)
+	wg.Wait()
+	assert.Equal(t, []interface{}{tweet, event}, allReceived)
+	assert.Equal(t, []interface{}{tweet}, tweetsReceived)
+
+	// subscribing to a closed Broker
+	late := broker.Subscribe(nil)
+	assertClosed(t, late.Messages, defaultTestTimeout)
+}
+
+func TestBroker_Overflow(t *testing.T) {
+	broker := NewBroker()
+	newest := broker.Subscribe(&SubscriptionParams{Buffer: 2, Overflow: OverflowDropNewest})
+	oldest := broker.Subscribe(&SubscriptionParams{Buffer: 2, Overflow: OverflowDropOldest})
+	unsubscribe := broker.Subscribe(&SubscriptionParams{Buffer: 2, Overflow: OverflowUnsubscribe})
+	for i := 1; i <= 4; i++ {
+		broker.Handle(i)
+	}
+	broker.Close()
+	assert.Equal(t, []interface{}{1, 2}, receiveAll(newest.Messages))
+	assert.Equal(t, int64(2), newest.Dropped())
+	assert.Equal(t, []interface{}{3, 4}, receiveAll(oldest.Messages))
+	assert.Equal(t, int64(2), oldest.Dropped())
+	assert.Equal(t, []interface{}{1, 2}, receiveAll(unsubscribe.Messages))
+	assert.Equal(t, int64(1), unsubscribe.Dropped())
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	broker := NewBroker()
+	blocked := broker.Subscribe(nil)
+	other := broker.Subscribe(&SubscriptionParams{Buffer: 2})
+	done := make(chan struct{})
+	go func() {
+		// blocks until the unbuffered subscriber unsubscribes
+		broker.Handle(1)
+		broker.Handle(2)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	blocked.Unsubscribe()
+	// unsubscribing twice is allowed
+	blocked.Unsubscribe()
+	assertDone(t, done, defaultTestTimeout)
+	assert.Equal(t, 1, <-other.Messages)
+	assert.Equal(t, 2, <-other.Messages)
+	_, ok := <-blocked.Messages
+	assert.False(t, ok)
+}
@@ -0,0 +1,230 @@
+package twitter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint records the ID of the last processed Tweet for named positions,
+// such as a stream or a timeline, so processing can resume after a restart.
+type Checkpoint interface {
+	// Load returns the Tweet ID saved for the key, or 0 if there is none.
+	Load(key string) (int64, error)
+	// Save records the Tweet ID for the key.
+	Save(key string, id int64) error
+}
+
+// MemoryCheckpoint is a Checkpoint kept in memory. It is safe for concurrent
+// use.
+type MemoryCheckpoint struct {
+	mu  sync.Mutex
+	ids map[string]int64
+}
+
+// NewMemoryCheckpoint returns a new, empty MemoryCheckpoint.
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{
+		ids: make(map[string]int64),
+	}
+}
+
+// Load returns the Tweet ID saved for the key, or 0 if there is none.
+func (c *MemoryCheckpoint) Load(key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ids[key], nil
+}
+
+// Save records the Tweet ID for the key.
+func (c *MemoryCheckpoint) Save(key string, id int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids[key] = id
+	return nil
+}
+
+// FileCheckpoint is a Checkpoint stored as a JSON object of keys to Tweet IDs
+// in a file. The file is replaced atomically on each Save, so it is never
+// left partially written. It is safe for concurrent use, but only one
+// FileCheckpoint should use a file at a time.
+type FileCheckpoint struct {
+	path string
+	mu   sync.Mutex
+	ids  map[string]int64
+}
+
+// NewFileCheckpoint returns a FileCheckpoint stored in the file at the given
+// path. The file is created on the first Save if it does not exist.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the Tweet ID saved for the key, or 0 if there is none.
+func (c *FileCheckpoint) Load(key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.read(); err != nil {
+		return 0, err
+	}
+	return c.ids[key], nil
+}
+
+// Save records the Tweet ID for the key and writes the file.
+func (c *FileCheckpoint) Save(key string, id int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.read(); err != nil {
+		return err
+	}
+	c.ids[key] = id
+	data, err := json.Marshal(c.ids)
+	if err != nil {
+		return err
+	}
+	// write a temporary file in the same directory and rename it over the
+	// checkpoint file
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	// flush to disk before the rename makes the new contents visible
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// read reads the checkpoint file the first time it is needed.
+func (c *FileCheckpoint) read() error {
+	if c.ids != nil {
+		return nil
+	}
+	ids := make(map[string]int64)
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return err
+		}
+	}
+	c.ids = ids
+	return nil
+}
+
+// SaveCheckpoint returns a DemuxMiddleware which saves the highest Tweet ID
+// handled by the wrapped Demux to the Checkpoint under the given key, after
+// the Tweet has been handled. The ID is saved at most once per interval, or
+// after every Tweet if the interval is 0, and the last ID is saved when
+// HandleChan returns. After a restart, Tweets handled since the last save are
+// handled again. Save errors are passed to onError, if it is not nil.
+//
+// SaveCheckpoint is only correct for a single consumer which handles Tweets
+// one at a time, in the order received, such as a SwitchDemux. Do not use it
+// with a WorkerDemux: one worker may handle a newer Tweet while older Tweets
+// are still queued for other workers, and those older Tweets would be skipped
+// after a restart.
+func SaveCheckpoint(checkpoint Checkpoint, key string, interval time.Duration, onError func(err error)) DemuxMiddleware {
+	return func(demux Demux) Demux {
+		return &checkpointDemux{
+			demux:      demux,
+			checkpoint: checkpoint,
+			key:        key,
+			interval:   interval,
+			onError:    onError,
+		}
+	}
+}
+
+// checkpointDemux is a Demux which checkpoints Tweets handled by a wrapped
+// Demux.
+type checkpointDemux struct {
+	demux      Demux
+	checkpoint Checkpoint
+	key        string
+	interval   time.Duration
+	onError    func(err error)
+	mu         sync.Mutex
+	// maxID is the highest Tweet ID handled and savedID the highest saved
+	maxID   int64
+	savedID int64
+	saved   time.Time
+}
+
+// Handle passes the message to the wrapped Demux, then records the ID of a
+// Tweet.
+func (d *checkpointDemux) Handle(message interface{}) {
+	d.demux.Handle(message)
+	d.handled(message)
+}
+
+// HandleChan passes messages to the wrapped Demux HandleChan, so it returns
+// when the wrapped Demux does, then saves the highest Tweet ID handled.
+func (d *checkpointDemux) HandleChan(messages <-chan interface{}) {
+	forwarded := make(chan interface{})
+	var last interface{}
+	go func() {
+		defer close(forwarded)
+		for message := range messages {
+			forwarded <- message
+			// the wrapped Demux handles messages in order, so the previous
+			// message has been handled once it receives the next
+			d.handled(last)
+			last = message
+		}
+	}()
+	d.demux.HandleChan(forwarded)
+	d.handled(last)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.save()
+}
+
+// handled records a handled message and saves the highest Tweet ID if the
+// interval has passed since the last save.
+func (d *checkpointDemux) handled(message interface{}) {
+	tweet, ok := message.(*Tweet)
+	if !ok {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if tweet.ID > d.maxID {
+		d.maxID = tweet.ID
+	}
+	if time.Since(d.saved) >= d.interval {
+		d.save()
+	}
+}
+
+// save saves the highest Tweet ID handled, if it is not saved yet. The caller
+// must hold mu.
+func (d *checkpointDemux) save() {
+	if d.maxID <= d.savedID {
+		return
+	}
+	d.saved = time.Now()
+	if err := d.checkpoint.Save(d.key, d.maxID); err != nil {
+		if d.onError != nil {
+			d.onError(err)
+		}
+		return
+	}
+	d.savedID = d.maxID
+}
@@ -0,0 +1,157 @@
+package twitter
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCheckpoint(t *testing.T) {
+	checkpoint := NewMemoryCheckpoint()
+	id, err := checkpoint.Load("filter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+	assert.NoError(t, checkpoint.Save("filter", 42))
+	id, err = checkpoint.Load("filter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	checkpoint := NewFileCheckpoint(path)
+	id, err := checkpoint.Load("filter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+	assert.NoError(t, checkpoint.Save("filter", 42))
+	assert.NoError(t, checkpoint.Save("home", 7))
+
+	// a new FileCheckpoint reads the saved IDs
+	checkpoint = NewFileCheckpoint(path)
+	id, err = checkpoint.Load("filter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	id, err = checkpoint.Load("home")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = NewFileCheckpoint(path).Load("filter")
+	assert.Error(t, err)
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	checkpoint := NewMemoryCheckpoint()
+	var handled, saved []int64
+	demux := NewSwitchDemux()
+	demux.Tweet = func(tweet *Tweet) {
+		// the checkpoint is saved after the Tweet is handled
+		id, _ := checkpoint.Load("filter")
+		handled = append(handled, tweet.ID)
+		saved = append(saved, id)
+	}
+	d := ChainDemux(demux, SaveCheckpoint(checkpoint, "filter", 0, nil))
+	for _, message := range []interface{}{&Tweet{ID: 1}, &Tweet{ID: 3}, &Tweet{ID: 2}, &Event{}} {
+		d.Handle(message)
+	}
+	assert.Equal(t, []int64{1, 3, 2}, handled)
+	assert.Equal(t, []int64{0, 1, 3}, saved)
+	id, err := checkpoint.Load("filter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestSaveCheckpoint_Interval(t *testing.T) {
+	checkpoint := &countingCheckpoint{Checkpoint: NewMemoryCheckpoint()}
+	d := ChainDemux(NewSwitchDemux(), SaveCheckpoint(checkpoint, "filter", time.Hour, nil))
+	for id := int64(1); id <= 3; id++ {
+		d.Handle(&Tweet{ID: id})
+	}
+	// only the first Tweet is saved within the interval
+	id, _ := checkpoint.Load("filter")
+	assert.Equal(t, int64(1), id)
+	assert.Equal(t, 1, checkpoint.saves)
+
+	// the last Tweet is saved when HandleChan returns
+	ch := make(chan interface{}, 2)
+	ch <- &Tweet{ID: 4}
+	ch <- &Event{}
+	close(ch)
+	d.HandleChan(ch)
+	id, _ = checkpoint.Load("filter")
+	assert.Equal(t, int64(4), id)
+	assert.Equal(t, 2, checkpoint.saves)
+}
+
+func TestSaveCheckpoint_HandleChan(t *testing.T) {
+	checkpoint := NewMemoryCheckpoint()
+	inner := &closingDemux{Demux: NewSwitchDemux()}
+	d := SaveCheckpoint(checkpoint, "filter", 0, nil)(inner)
+	ch := make(chan interface{})
+	go func() {
+		for _, id := range []int64{1, 3, 2} {
+			ch <- &Tweet{ID: id}
+		}
+		close(ch)
+	}()
+	d.HandleChan(ch)
+	// the wrapped HandleChan handles the messages
+	assert.Equal(t, 3, inner.handled)
+	assert.True(t, inner.closed)
+	id, _ := checkpoint.Load("filter")
+	assert.Equal(t, int64(3), id)
+}
+
+// countingCheckpoint counts the saves to a Checkpoint.
+type countingCheckpoint struct {
+	Checkpoint
+	saves int
+}
+
+func (c *countingCheckpoint) Save(key string, id int64) error {
+	c.saves++
+	return c.Checkpoint.Save(key, id)
+}
+
+// closingDemux records the messages its HandleChan handles and whether it
+// returned.
+type closingDemux struct {
+	Demux
+	handled int
+	closed  bool
+}
+
+func (d *closingDemux) HandleChan(messages <-chan interface{}) {
+	for message := range messages {
+		d.Handle(message)
+		d.handled++
+	}
+	d.closed = true
+}
+
+type failingCheckpoint struct{}
+
+func (failingCheckpoint) Load(key string) (int64, error)  { return 0, errors.New("load failed") }
+func (failingCheckpoint) Save(key string, id int64) error { return errors.New("save failed") }
+
+func TestSaveCheckpoint_Error(t *testing.T) {
+	var errs []error
+	d := ChainDemux(NewSwitchDemux(), SaveCheckpoint(failingCheckpoint{}, "filter", 0, func(err error) {
+		errs = append(errs, err)
+	}))
+	d.Handle(&Tweet{ID: 1})
+	assert.Equal(t, []error{errors.New("save failed")}, errs)
+}
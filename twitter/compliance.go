@@ -0,0 +1,190 @@
+package twitter
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// TweetStore is storage for Tweets which must be kept compliant with
+// deletion, scrub_geo, and withheld content notices.
+// https://dev.twitter.com/overview/terms/agreement-and-policy
+type TweetStore interface {
+	// DeleteTweet deletes the Tweet with the given ID.
+	DeleteTweet(id int64) error
+	// ScrubGeo removes the Coordinates and Place of the user's Tweets with
+	// IDs up to and including upToStatusID.
+	ScrubGeo(userID, upToStatusID int64) error
+	// WithholdTweet marks the Tweet with the given ID as withheld in the
+	// given countries.
+	WithholdTweet(id int64, countries []string) error
+	// WithholdUser marks the user's Tweets as withheld in the given
+	// countries.
+	WithholdUser(userID int64, countries []string) error
+}
+
+// Compliance applies StatusDeletion, LocationDeletion, StatusWithheld, and
+// UserWithheld stream messages to a TweetStore. Compliance is a Demux, so it
+// can receive a Stream's messages directly or be called from the handlers
+// of another Demux. Other messages are ignored.
+type Compliance struct {
+	store TweetStore
+	// OnError is called with messages the TweetStore failed to apply, if set.
+	OnError func(message interface{}, err error)
+}
+
+// NewCompliance returns a new Compliance which applies messages to the given
+// TweetStore.
+func NewCompliance(store TweetStore) *Compliance {
+	return &Compliance{store: store}
+}
+
+// Apply applies a compliance message to the TweetStore and returns any
+// TweetStore error. Site Stream messages are unwrapped.
+func (c *Compliance) Apply(message interface{}) error {
+	switch msg := message.(type) {
+	case *StatusDeletion:
+		return c.store.DeleteTweet(msg.ID)
+	case *LocationDeletion:
+		return c.store.ScrubGeo(msg.UserID, msg.UpToStatusID)
+	case *StatusWithheld:
+		return c.store.WithholdTweet(msg.ID, msg.WithheldInCountries)
+	case *UserWithheld:
+		return c.store.WithholdUser(msg.ID, msg.WithheldInCountries)
+	case *SiteStreamMessage:
+		return c.Apply(msg.Message)
+	}
+	return nil
+}
+
+// Handle applies the message, passing errors to the OnError func.
+func (c *Compliance) Handle(message interface{}) {
+	if err := c.Apply(message); err != nil && c.OnError != nil {
+		c.OnError(message, err)
+	}
+}
+
+// HandleChan applies messages until the channel is closed, passing errors to
+// the OnError func.
+func (c *Compliance) HandleChan(messages <-chan interface{}) {
+	for message := range messages {
+		c.Handle(message)
+	}
+}
+
+// MemoryTweetStore is an in-memory TweetStore. It is safe for concurrent use.
+type MemoryTweetStore struct {
+	mu     sync.RWMutex
+	tweets map[int64]*Tweet
+}
+
+// NewMemoryTweetStore returns a new, empty MemoryTweetStore.
+func NewMemoryTweetStore() *MemoryTweetStore {
+	return &MemoryTweetStore{
+		tweets: make(map[int64]*Tweet),
+	}
+}
+
+// Put stores a copy of the Tweet, including its slices and maps, replacing any Tweet with the same ID.
+func (s *MemoryTweetStore) Put(tweet *Tweet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tweets[tweet.ID] = copyTweet(tweet)
+}
+
+// Get returns a copy of the Tweet with the given ID, including its slices and
+// maps, and true, or nil and false if it is not stored.
+func (s *MemoryTweetStore) Get(id int64) (*Tweet, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.tweets[id]
+	if !ok {
+		return nil, false
+	}
+	return copyTweet(stored), true
+}
+
+// Len returns the number of stored Tweets.
+func (s *MemoryTweetStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tweets)
+}
+
+// DeleteTweet deletes the Tweet with the given ID.
+func (s *MemoryTweetStore) DeleteTweet(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tweets, id)
+	return nil
+}
+
+// ScrubGeo removes the Coordinates and Place of the user's Tweets with IDs
+// up to and including upToStatusID.
+func (s *MemoryTweetStore) ScrubGeo(userID, upToStatusID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, tweet := range s.tweets {
+		if id <= upToStatusID && tweet.User != nil && tweet.User.ID == userID {
+			tweet.Coordinates = nil
+			tweet.Place = nil
+		}
+	}
+	return nil
+}
+
+// WithholdTweet marks the Tweet with the given ID as withheld in the given
+// countries, with withheld scope "status".
+func (s *MemoryTweetStore) WithholdTweet(id int64, countries []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tweet, ok := s.tweets[id]; ok {
+		tweet.WithheldInCountries = copyStrings(countries)
+		tweet.WithheldScope = "status"
+	}
+	return nil
+}
+
+// WithholdUser marks the user's Tweets as withheld in the given countries,
+// with withheld scope "user".
+func (s *MemoryTweetStore) WithholdUser(userID int64, countries []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tweet := range s.tweets {
+		if tweet.User != nil && tweet.User.ID == userID {
+			tweet.WithheldInCountries = copyStrings(countries)
+			tweet.WithheldScope = "user"
+		}
+	}
+	return nil
+}
+
+// copyTweet returns a copy of the Tweet which shares no slices or maps with
+// it. Nested structs, such as the User, are shared.
+func copyTweet(tweet *Tweet) *Tweet {
+	copied := *tweet
+	if tweet.Contributors != nil {
+		copied.Contributors = append([]Contributor(nil), tweet.Contributors...)
+	}
+	copied.WithheldInCountries = copyStrings(tweet.WithheldInCountries)
+	if tweet.Scopes != nil {
+		copied.Scopes = make(map[string]interface{}, len(tweet.Scopes))
+		for key, value := range tweet.Scopes {
+			copied.Scopes[key] = value
+		}
+	}
+	if tweet.Extra != nil {
+		copied.Extra = make(map[string]json.RawMessage, len(tweet.Extra))
+		for key, value := range tweet.Extra {
+			copied.Extra[key] = value
+		}
+	}
+	return &copied
+}
+
+// copyStrings returns a copy of a slice of strings, or nil if it is nil.
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
@@ -0,0 +1,103 @@
+package twitter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompliance_MemoryTweetStore(t *testing.T) {
+	store := NewMemoryTweetStore()
+	gopher := &User{ID: 12}
+	place := &Place{ID: "5a110d312052166f"}
+	coordinates := &Coordinates{Coordinates: [2]float64{-122.4, 37.7}, Type: "Point"}
+	store.Put(&Tweet{ID: 1, User: gopher, Coordinates: coordinates, Place: place})
+	store.Put(&Tweet{ID: 2, User: gopher, Coordinates: coordinates, Place: place})
+	store.Put(&Tweet{ID: 3, User: gopher, Coordinates: coordinates, Place: place})
+	store.Put(&Tweet{ID: 4, User: &User{ID: 13}, Coordinates: coordinates, Place: place})
+
+	compliance := NewCompliance(store)
+	ch := make(chan interface{})
+	go func() {
+		ch <- &Tweet{ID: 5}
+		ch <- &StatusDeletion{ID: 1, UserID: 12}
+		ch <- &LocationDeletion{UserID: 12, UpToStatusID: 2}
+		ch <- &SiteStreamMessage{ForUser: 13, Message: &StatusWithheld{ID: 4, UserID: 13, WithheldInCountries: []string{"DE"}}}
+		ch <- &UserWithheld{ID: 12, WithheldInCountries: []string{"FR", "DE"}}
+		close(ch)
+	}()
+	compliance.HandleChan(ch)
+
+	assert.Equal(t, 3, store.Len())
+	_, ok := store.Get(1)
+	assert.False(t, ok)
+	tweet, ok := store.Get(2)
+	assert.True(t, ok)
+	expected := &Tweet{ID: 2, User: gopher, WithheldInCountries: []string{"FR", "DE"}, WithheldScope: "user"}
+	assert.Equal(t, expected, tweet)
+	tweet, _ = store.Get(3)
+	expected = &Tweet{ID: 3, User: gopher, Coordinates: coordinates, Place: place, WithheldInCountries: []string{"FR", "DE"}, WithheldScope: "user"}
+	assert.Equal(t, expected, tweet)
+	tweet, _ = store.Get(4)
+	expected = &Tweet{ID: 4, User: &User{ID: 13}, Coordinates: coordinates, Place: place, WithheldInCountries: []string{"DE"}, WithheldScope: "status"}
+	assert.Equal(t, expected, tweet)
+}
+
+func TestCompliance_MemoryTweetStoreCopies(t *testing.T) {
+	store := NewMemoryTweetStore()
+	tweet := &Tweet{ID: 1, User: &User{ID: 12}, WithheldInCountries: []string{"DE"}}
+	store.Put(tweet)
+	tweet.WithheldInCountries[0] = "FR"
+	stored, _ := store.Get(1)
+	assert.Equal(t, []string{"DE"}, stored.WithheldInCountries)
+
+	countries := []string{"GB"}
+	assert.Nil(t, store.WithholdUser(12, countries))
+	countries[0] = "FR"
+	stored, _ = store.Get(1)
+	assert.Equal(t, []string{"GB"}, stored.WithheldInCountries)
+	// changing a returned Tweet does not change the store
+	stored.WithheldInCountries[0] = "FR"
+	stored, _ = store.Get(1)
+	assert.Equal(t, []string{"GB"}, stored.WithheldInCountries)
+
+	store.Put(&Tweet{ID: 2, WithheldInCountries: []string{"DE"}})
+	countries = []string{"GB"}
+	assert.Nil(t, store.WithholdTweet(2, countries))
+	countries[0] = "FR"
+	stored, _ = store.Get(2)
+	assert.Equal(t, []string{"GB"}, stored.WithheldInCountries)
+}
+
+func TestCompliance_OnError(t *testing.T) {
+	compliance := NewCompliance(failingStore{})
+	var failed []interface{}
+	compliance.OnError = func(message interface{}, err error) {
+		assert.EqualError(t, err, "store unavailable")
+		failed = append(failed, message)
+	}
+	deletion := &StatusDeletion{ID: 1}
+	compliance.Handle(deletion)
+	compliance.Handle(&Tweet{ID: 1})
+	assert.Equal(t, []interface{}{deletion}, failed)
+}
+
+// failingStore is a TweetStore which always fails.
+type failingStore struct{}
+
+func (failingStore) DeleteTweet(id int64) error {
+	return errors.New("store unavailable")
+}
+
+func (failingStore) ScrubGeo(userID, upToStatusID int64) error {
+	return errors.New("store unavailable")
+}
+
+func (failingStore) WithholdTweet(id int64, countries []string) error {
+	return errors.New("store unavailable")
+}
+
+func (failingStore) WithholdUser(userID int64, countries []string) error {
+	return errors.New("store unavailable")
+}
@@ -0,0 +1,243 @@
+package twitter
+
+import (
+	"net/http"
+	"sort"
+)
+
+// lookupBatchSize is the maximum number of ids per StatusService.Lookup.
+const lookupBatchSize = 100
+
+// Conversation is a tree of Tweets linked by their InReplyToStatusID.
+type Conversation struct {
+	// Root is the first Tweet of the conversation, or the first missing
+	// Tweet above the requested Tweet.
+	Root *ConversationNode
+	// Nodes are the nodes of the conversation by Tweet ID, including
+	// Detached nodes and their replies.
+	Nodes map[int64]*ConversationNode
+	// Detached are missing Tweets, such as deleted replies, which searched
+	// replies reply to but whose parent is unknown, ordered by ID. They may
+	// belong to other conversations of the same authors.
+	Detached []*ConversationNode
+}
+
+// ConversationNode is a Tweet in a Conversation.
+type ConversationNode struct {
+	ID int64
+	// Tweet is nil if the Tweet is missing, because it was deleted, is
+	// protected, or could not be found.
+	Tweet  *Tweet
+	Parent *ConversationNode
+	// Replies are the replies to the Tweet, ordered by ID.
+	Replies []*ConversationNode
+}
+
+// Missing returns true if the node's Tweet is missing.
+func (n *ConversationNode) Missing() bool {
+	return n.Tweet == nil
+}
+
+// ConversationParams are the parameters for StatusService.Conversation.
+type ConversationParams struct {
+	// SkipReplies skips searching for replies below the requested Tweet.
+	SkipReplies bool
+	// SearchPages is the number of pages of search results to check for
+	// replies to each author (default 1).
+	SearchPages int
+	// TweetMode is the tweet_mode of the lookups and searches, "extended"
+	// for untruncated FullText.
+	TweetMode string
+}
+
+// Conversation rebuilds the conversation of the Tweet with the given id. The
+// Tweets it replies to are looked up up to the root of the conversation, and
+// replies are found by searching recent Tweets to the authors of the root and
+// the requested Tweet, looking up the Tweets they reply to in batches. Replies
+// below a missing reply, which cannot be placed in the conversation, are kept
+// below a Detached node. Search only finds Tweets from the last 7 days.
+// https://dev.twitter.com/rest/reference/get/statuses/lookup
+// https://dev.twitter.com/rest/reference/get/search/tweets
+func (s *StatusService) Conversation(id int64, params *ConversationParams) (*Conversation, *http.Response, error) {
+	if params == nil {
+		params = &ConversationParams{}
+	}
+	c := &Conversation{Nodes: make(map[int64]*ConversationNode)}
+	var resp *http.Response
+
+	// walk up the reply chain to the root
+	node := &ConversationNode{ID: id}
+	c.Nodes[id] = node
+	for {
+		tweets, lookupResp, err := s.lookupAll([]int64{node.ID}, params.TweetMode)
+		resp = lookupResp
+		if err != nil {
+			return nil, resp, err
+		}
+		node.Tweet = tweets[node.ID]
+		parentID := int64(0)
+		if node.Tweet != nil {
+			parentID = node.Tweet.InReplyToStatusID
+		}
+		if parentID == 0 || c.Nodes[parentID] != nil {
+			break
+		}
+		parent := &ConversationNode{ID: parentID}
+		c.link(parent, node)
+		node = parent
+	}
+	c.Root = node
+	if params.SkipReplies {
+		return c, resp, nil
+	}
+
+	// search for replies and look up the Tweets they reply to
+	candidates := make(map[int64]*Tweet)
+	for _, screenName := range c.authors(id) {
+		searchResp, err := s.searchReplies(screenName, c.Root.ID, params, candidates)
+		resp = searchResp
+		if err != nil {
+			return nil, resp, err
+		}
+	}
+	looked := make(map[int64]bool)
+	for {
+		var unknown []int64
+		for _, tweet := range candidates {
+			parentID := tweet.InReplyToStatusID
+			// replies are newer than the root, older Tweets are elsewhere
+			if parentID > c.Root.ID && candidates[parentID] == nil && c.Nodes[parentID] == nil && !looked[parentID] {
+				unknown = append(unknown, parentID)
+				looked[parentID] = true
+			}
+		}
+		if len(unknown) == 0 {
+			break
+		}
+		tweets, lookupResp, err := s.lookupAll(unknown, params.TweetMode)
+		resp = lookupResp
+		if err != nil {
+			return nil, resp, err
+		}
+		for tweetID, tweet := range tweets {
+			candidates[tweetID] = tweet
+		}
+	}
+	// keep missing replies, whose parents are unknown, as detached nodes
+	for _, tweet := range candidates {
+		parentID := tweet.InReplyToStatusID
+		if looked[parentID] && candidates[parentID] == nil && c.Nodes[parentID] == nil {
+			detached := &ConversationNode{ID: parentID}
+			c.Nodes[parentID] = detached
+			c.Detached = append(c.Detached, detached)
+		}
+	}
+	sort.Sort(nodesByID(c.Detached))
+	for linked := true; linked; {
+		linked = false
+		for tweetID, tweet := range candidates {
+			if parent := c.Nodes[tweet.InReplyToStatusID]; parent != nil && c.Nodes[tweetID] == nil {
+				c.link(parent, &ConversationNode{ID: tweetID, Tweet: tweet})
+				linked = true
+			}
+		}
+	}
+	for _, node := range c.Nodes {
+		sort.Sort(nodesByID(node.Replies))
+	}
+	return c, resp, nil
+}
+
+// link adds the parent and child nodes to the conversation and links them.
+func (c *Conversation) link(parent, child *ConversationNode) {
+	c.Nodes[parent.ID] = parent
+	c.Nodes[child.ID] = child
+	child.Parent = parent
+	parent.Replies = append(parent.Replies, child)
+}
+
+// authors returns the screen names of the authors of the root and of the
+// Tweet with the given id.
+func (c *Conversation) authors(id int64) []string {
+	var screenNames []string
+	for _, node := range []*ConversationNode{c.Root, c.Nodes[id]} {
+		screenName := ""
+		if node.Tweet != nil && node.Tweet.User != nil {
+			screenName = node.Tweet.User.ScreenName
+		} else if len(node.Replies) > 0 && node.Replies[0].Tweet != nil {
+			// the author of a missing Tweet is known from its reply
+			screenName = node.Replies[0].Tweet.InReplyToScreenName
+		}
+		if screenName != "" && (len(screenNames) == 0 || screenNames[0] != screenName) {
+			screenNames = append(screenNames, screenName)
+		}
+	}
+	return screenNames
+}
+
+// lookupAll looks up Tweets in batches, returning those found by ID.
+func (s *StatusService) lookupAll(ids []int64, tweetMode string) (map[int64]*Tweet, *http.Response, error) {
+	found := make(map[int64]*Tweet)
+	var resp *http.Response
+	for start := 0; start < len(ids); start += lookupBatchSize {
+		end := start + lookupBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		tweets, lookupResp, err := s.Lookup(ids[start:end], &StatusLookupParams{TweetMode: tweetMode})
+		resp = lookupResp
+		if err != nil {
+			return nil, resp, err
+		}
+		for i := range tweets {
+			found[tweets[i].ID] = &tweets[i]
+		}
+	}
+	return found, resp, nil
+}
+
+// searchReplies adds recent Tweets to the screen name since the sinceID to
+// the replies.
+func (s *StatusService) searchReplies(screenName string, sinceID int64, params *ConversationParams, replies map[int64]*Tweet) (*http.Response, error) {
+	pages := params.SearchPages
+	if pages <= 0 {
+		pages = 1
+	}
+	var resp *http.Response
+	maxID := int64(0)
+	for page := 0; page < pages; page++ {
+		search, searchResp, err := s.search.Search(&SearchParams{
+			Query:      "to:" + screenName,
+			ResultType: "recent",
+			Count:      100,
+			SinceID:    sinceID,
+			MaxID:      maxID,
+			TweetMode:  params.TweetMode,
+		})
+		resp = searchResp
+		if err != nil {
+			return resp, err
+		}
+		if len(search.Statuses) == 0 {
+			break
+		}
+		for _, tweet := range search.Statuses {
+			if tweet.InReplyToStatusID != 0 {
+				replies[tweet.ID] = tweet
+			}
+		}
+		// results are newest first, page back with max_id
+		maxID = search.Statuses[len(search.Statuses)-1].ID - 1
+		if maxID <= sinceID {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// nodesByID sorts ConversationNodes by ID.
+type nodesByID []*ConversationNode
+
+func (n nodesByID) Len() int           { return len(n) }
+func (n nodesByID) Less(i, j int) bool { return n[i].ID < n[j].ID }
+func (n nodesByID) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
@@ -0,0 +1,165 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// conversationTweet is a Tweet by author replying to the Tweet parent by
+// parentAuthor.
+type conversationTweet struct {
+	id, parent           int64
+	author, parentAuthor string
+}
+
+func (t conversationTweet) json() string {
+	return fmt.Sprintf(`{"id": %d, "in_reply_to_status_id": %d, "in_reply_to_screen_name": %q, "user": {"screen_name": %q}}`,
+		t.id, t.parent, t.parentAuthor, t.author)
+}
+
+// conversationServer serves lookups of the tweets and searches for replies,
+// and records the ids of each lookup.
+func conversationServer(t *testing.T, mux *http.ServeMux, tweets []conversationTweet, replies map[string][]conversationTweet) *[][]string {
+	byID := make(map[string]conversationTweet)
+	for _, tweet := range tweets {
+		byID[strconv.FormatInt(tweet.id, 10)] = tweet
+	}
+	var mu sync.Mutex
+	var lookups [][]string
+	mux.HandleFunc("/1.1/statuses/lookup.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		sort.Strings(ids)
+		mu.Lock()
+		lookups = append(lookups, ids)
+		mu.Unlock()
+		var found []string
+		for _, id := range ids {
+			if tweet, ok := byID[id]; ok {
+				found = append(found, tweet.json())
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(found, ","))
+	})
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, map[string]string{"q": r.URL.Query().Get("q"), "result_type": "recent", "count": "100", "since_id": "10"}, r)
+		var found []string
+		for _, tweet := range replies[r.URL.Query().Get("q")] {
+			found = append(found, tweet.json())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"statuses": [%s]}`, strings.Join(found, ","))
+	})
+	return &lookups
+}
+
+// conversationIDs returns the IDs of the node and its replies as nested
+// slices, with missing nodes negated.
+func conversationIDs(node *ConversationNode) []interface{} {
+	id := node.ID
+	if node.Missing() {
+		id = -id
+	}
+	ids := []interface{}{id}
+	for _, reply := range node.Replies {
+		ids = append(ids, conversationIDs(reply))
+	}
+	return ids
+}
+
+func TestStatusService_Conversation(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	root := conversationTweet{10, 0, "alice", ""}
+	tweet := conversationTweet{20, 10, "bob", "alice"}
+	reply := conversationTweet{30, 20, "alice", "bob"}
+	sibling := conversationTweet{40, 10, "carol", "alice"}
+	unsearched := conversationTweet{50, 20, "erin", "bob"}
+	belowUnsearched := conversationTweet{60, 50, "dave", "erin"}
+	belowDeleted := conversationTweet{70, 65, "frank", "bob"}
+	older := conversationTweet{80, 5, "grace", "bob"}
+	lookups := conversationServer(t, mux,
+		[]conversationTweet{root, tweet, reply, sibling, unsearched, belowUnsearched, belowDeleted, older},
+		map[string][]conversationTweet{
+			"to:alice": {belowUnsearched, sibling, tweet},
+			"to:bob":   {older, belowDeleted, reply},
+		})
+
+	client := NewClient(httpClient)
+	conversation, _, err := client.Statuses.Conversation(20, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(10),
+		[]interface{}{int64(20),
+			[]interface{}{int64(30)},
+			[]interface{}{int64(50), []interface{}{int64(60)}},
+		},
+		[]interface{}{int64(40)},
+	}, conversationIDs(conversation.Root))
+	// the reply to the deleted Tweet is kept below a detached node
+	if assert.Len(t, conversation.Detached, 1) {
+		assert.Equal(t, []interface{}{int64(-65), []interface{}{int64(70)}}, conversationIDs(conversation.Detached[0]))
+		assert.Nil(t, conversation.Detached[0].Parent)
+	}
+	assert.Len(t, conversation.Nodes, 8)
+	assert.Equal(t, conversation.Nodes[10], conversation.Nodes[20].Parent)
+	assert.Equal(t, "erin", conversation.Nodes[50].Tweet.User.ScreenName)
+	// the Tweet, the root, then the unknown replied to Tweets in a batch
+	assert.Equal(t, [][]string{{"20"}, {"10"}, {"50", "65"}}, *lookups)
+}
+
+func TestStatusService_ConversationMissingRoot(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	reply := conversationTweet{30, 20, "alice", "bob"}
+	conversationServer(t, mux, []conversationTweet{reply}, nil)
+
+	client := NewClient(httpClient)
+	conversation, _, err := client.Statuses.Conversation(30, &ConversationParams{SkipReplies: true})
+	assert.Nil(t, err)
+	assert.True(t, conversation.Root.Missing())
+	assert.Equal(t, []interface{}{int64(-20), []interface{}{int64(30)}}, conversationIDs(conversation.Root))
+	assert.Equal(t, []string{"bob", "alice"}, conversation.authors(30))
+}
+
+func TestStatusService_ConversationLookupBatches(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	lookups := conversationServer(t, mux, nil, nil)
+
+	client := NewClient(httpClient)
+	ids := make([]int64, 150)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	found, _, err := client.Statuses.lookupAll(ids, "")
+	assert.Nil(t, err)
+	assert.Empty(t, found)
+	if assert.Len(t, *lookups, 2) {
+		assert.Len(t, (*lookups)[0], 100)
+		assert.Len(t, (*lookups)[1], 50)
+	}
+}
+
+func TestStatusService_ConversationAPIError(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/1.1/statuses/lookup.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(429)
+		fmt.Fprintf(w, `{"errors": [{"message": "Rate limit exceeded", "code": 88}]}`)
+	})
+
+	client := NewClient(httpClient)
+	conversation, resp, err := client.Statuses.Conversation(20, nil)
+	assert.Nil(t, conversation)
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.Equal(t, APIError{Errors: []ErrorDetail{{Message: "Rate limit exceeded", Code: 88}}}, err)
+}
@@ -86,3 +86,81 @@ func (d SwitchDemux) HandleChan(messages <-chan interface{}) {
 		d.Handle(message)
 	}
 }
+
+// EventDemux receives Events and uses a switch on the event name to send each
+// Event to a typed handler function. Events whose handler is nil, or which
+// have no typed handler, are passed to the Other func if it is set. Set a
+// SwitchDemux Event func to an EventDemux Handle func to use both, passing the
+// generic Event handler as Other.
+type EventDemux struct {
+	Favorite             func(source, target *User, tweet *Tweet)
+	Unfavorite           func(source, target *User, tweet *Tweet)
+	FavoritedRetweet     func(source, target *User, tweet *Tweet)
+	RetweetedRetweet     func(source, target *User, tweet *Tweet)
+	QuotedTweet          func(source, target *User, tweet *Tweet)
+	Follow               func(source, target *User)
+	Unfollow             func(source, target *User)
+	Block                func(source, target *User)
+	Unblock              func(source, target *User)
+	Mute                 func(source, target *User)
+	Unmute               func(source, target *User)
+	UserUpdate           func(user *User)
+	ListCreated          func(source *User, list *List)
+	ListDestroyed        func(source *User, list *List)
+	ListUpdated          func(source *User, list *List)
+	ListMemberAdded      func(source, target *User, list *List)
+	ListMemberRemoved    func(source, target *User, list *List)
+	ListUserSubscribed   func(source, target *User, list *List)
+	ListUserUnsubscribed func(source, target *User, list *List)
+	Other                func(event *Event)
+}
+
+// Handle determines the name of an Event and calls the corresponding handler
+// function with the Event source, target, and target object. Events without
+// a handler are passed to the Other func.
+func (d EventDemux) Handle(event *Event) {
+	tweet, _ := event.TargetObject.(*Tweet)
+	list, _ := event.TargetObject.(*List)
+	switch {
+	case event.Event == FavoriteEvent && d.Favorite != nil:
+		d.Favorite(event.Source, event.Target, tweet)
+	case event.Event == UnfavoriteEvent && d.Unfavorite != nil:
+		d.Unfavorite(event.Source, event.Target, tweet)
+	case event.Event == FavoritedRetweetEvent && d.FavoritedRetweet != nil:
+		d.FavoritedRetweet(event.Source, event.Target, tweet)
+	case event.Event == RetweetedRetweetEvent && d.RetweetedRetweet != nil:
+		d.RetweetedRetweet(event.Source, event.Target, tweet)
+	case event.Event == QuotedTweetEvent && d.QuotedTweet != nil:
+		d.QuotedTweet(event.Source, event.Target, tweet)
+	case event.Event == FollowEvent && d.Follow != nil:
+		d.Follow(event.Source, event.Target)
+	case event.Event == UnfollowEvent && d.Unfollow != nil:
+		d.Unfollow(event.Source, event.Target)
+	case event.Event == BlockEvent && d.Block != nil:
+		d.Block(event.Source, event.Target)
+	case event.Event == UnblockEvent && d.Unblock != nil:
+		d.Unblock(event.Source, event.Target)
+	case event.Event == MuteEvent && d.Mute != nil:
+		d.Mute(event.Source, event.Target)
+	case event.Event == UnmuteEvent && d.Unmute != nil:
+		d.Unmute(event.Source, event.Target)
+	case event.Event == UserUpdateEvent && d.UserUpdate != nil:
+		d.UserUpdate(event.Source)
+	case event.Event == ListCreatedEvent && d.ListCreated != nil:
+		d.ListCreated(event.Source, list)
+	case event.Event == ListDestroyedEvent && d.ListDestroyed != nil:
+		d.ListDestroyed(event.Source, list)
+	case event.Event == ListUpdatedEvent && d.ListUpdated != nil:
+		d.ListUpdated(event.Source, list)
+	case event.Event == ListMemberAddedEvent && d.ListMemberAdded != nil:
+		d.ListMemberAdded(event.Source, event.Target, list)
+	case event.Event == ListMemberRemovedEvent && d.ListMemberRemoved != nil:
+		d.ListMemberRemoved(event.Source, event.Target, list)
+	case event.Event == ListUserSubscribedEvent && d.ListUserSubscribed != nil:
+		d.ListUserSubscribed(event.Source, event.Target, list)
+	case event.Event == ListUserUnsubscribedEvent && d.ListUserUnsubscribed != nil:
+		d.ListUserUnsubscribed(event.Source, event.Target, list)
+	case d.Other != nil:
+		d.Other(event)
+	}
+}
@@ -0,0 +1,215 @@
+package twitter
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DemuxMiddleware wraps a Demux to return a Demux which changes how messages
+// are handled, such as dropping some messages before they reach the wrapped
+// Demux.
+type DemuxMiddleware func(demux Demux) Demux
+
+// ChainDemux wraps the Demux in each middleware. The first middleware is the
+// outermost, so it sees messages first.
+func ChainDemux(demux Demux, middleware ...DemuxMiddleware) Demux {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		demux = middleware[i](demux)
+	}
+	return demux
+}
+
+// FilterDemux returns a DemuxMiddleware which passes only the messages for
+// which keep returns true to the wrapped Demux.
+func FilterDemux(keep func(message interface{}) bool) DemuxMiddleware {
+	return func(demux Demux) Demux {
+		return &filterDemux{demux: demux, keep: keep}
+	}
+}
+
+// filterDemux is a Demux which drops messages before a wrapped Demux.
+type filterDemux struct {
+	demux Demux
+	keep  func(message interface{}) bool
+}
+
+// Handle passes the message to the wrapped Demux if it is kept.
+func (d *filterDemux) Handle(message interface{}) {
+	if d.keep(message) {
+		d.demux.Handle(message)
+	}
+}
+
+// HandleChan passes kept messages to the wrapped Demux HandleChan, so it
+// returns when the wrapped Demux does.
+func (d *filterDemux) HandleChan(messages <-chan interface{}) {
+	kept := make(chan interface{})
+	go func() {
+		defer close(kept)
+		for message := range messages {
+			if d.keep(message) {
+				kept <- message
+			}
+		}
+	}()
+	d.demux.HandleChan(kept)
+}
+
+// DropRetweets returns a DemuxMiddleware which drops retweets. Quote Tweets
+// and other messages are kept.
+func DropRetweets() DemuxMiddleware {
+	return FilterDemux(func(message interface{}) bool {
+		tweet, ok := message.(*Tweet)
+		return !ok || tweet.RetweetedStatus == nil
+	})
+}
+
+// FilterLanguages returns a DemuxMiddleware which drops Tweets whose lang is
+// not one of the given BCP 47 language codes. Other messages are kept.
+func FilterLanguages(langs ...string) DemuxMiddleware {
+	keep := make(map[string]bool)
+	for _, lang := range langs {
+		keep[strings.ToLower(lang)] = true
+	}
+	return FilterDemux(func(message interface{}) bool {
+		tweet, ok := message.(*Tweet)
+		return !ok || keep[strings.ToLower(tweet.Lang)]
+	})
+}
+
+// FilterMinFollowers returns a DemuxMiddleware which drops Tweets by users
+// with fewer than the given number of followers. Other messages are kept.
+func FilterMinFollowers(followers int) DemuxMiddleware {
+	return FilterDemux(func(message interface{}) bool {
+		tweet, ok := message.(*Tweet)
+		return !ok || (tweet.User != nil && tweet.User.FollowersCount >= followers)
+	})
+}
+
+// DedupeByID returns a DemuxMiddleware which drops Tweets and Direct Messages
+// whose ID was among the last size IDs handled. Other messages are kept.
+func DedupeByID(size int) DemuxMiddleware {
+	if size < 1 {
+		size = 1
+	}
+	var mu sync.Mutex
+	seen := make(map[int64]struct{})
+	// recent is a ring of the remembered IDs, oldest at head once full
+	recent := make([]int64, 0, size)
+	head := 0
+	return FilterDemux(func(message interface{}) bool {
+		var id int64
+		switch msg := message.(type) {
+		case *Tweet:
+			id = msg.ID
+		case *DirectMessage:
+			id = msg.ID
+		default:
+			return true
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := seen[id]; ok {
+			return false
+		}
+		// replace the oldest ID once full
+		if len(recent) < size {
+			recent = append(recent, id)
+		} else {
+			delete(seen, recent[head])
+			recent[head] = id
+			head = (head + 1) % size
+		}
+		seen[id] = struct{}{}
+		return true
+	})
+}
+
+// SampleTweets returns a DemuxMiddleware which keeps about the given fraction
+// of Tweets, from 0 to 1. Tweets are chosen by a hash of their ID, so the same
+// Tweets are kept each time. Other messages are kept.
+func SampleTweets(rate float64) DemuxMiddleware {
+	return FilterDemux(func(message interface{}) bool {
+		tweet, ok := message.(*Tweet)
+		if !ok {
+			return true
+		}
+		hash := fnv.New32a()
+		hash.Write([]byte(strconv.FormatInt(tweet.ID, 10)))
+		return float64(hash.Sum32()) < rate*(1<<32)
+	})
+}
+
+// RecoverPanics returns a DemuxMiddleware which recovers from panics in the
+// wrapped Demux and passes the message and recovered value to onPanic, if it
+// is not nil. Handling continues with the next message. RecoverPanics only
+// recovers from panics on the goroutine calling Handle, so it should wrap the
+// Demux with the handlers (e.g. inside a WorkerDemux, not outside it).
+func RecoverPanics(onPanic func(message, recovered interface{})) DemuxMiddleware {
+	return func(demux Demux) Demux {
+		return &recoverDemux{demux: demux, onPanic: onPanic}
+	}
+}
+
+// recoverDemux is a Demux which recovers from panics in a wrapped Demux.
+type recoverDemux struct {
+	demux   Demux
+	onPanic func(message, recovered interface{})
+}
+
+// Handle passes the message to the wrapped Demux, recovering from panics.
+func (d *recoverDemux) Handle(message interface{}) {
+	defer func() {
+		if recovered := recover(); recovered != nil && d.onPanic != nil {
+			d.onPanic(message, recovered)
+		}
+	}()
+	d.demux.Handle(message)
+}
+
+// HandleChan passes messages to the wrapped Demux HandleChan, so it returns
+// when the wrapped Demux does. After a panic, the wrapped HandleChan is called
+// again to handle the remaining messages.
+func (d *recoverDemux) HandleChan(messages <-chan interface{}) {
+	forwarded := make(chan interface{})
+	// lastSent replies with the last message the wrapped Demux received
+	lastSent := make(chan chan interface{})
+	defer close(lastSent)
+	go func() {
+		var last interface{}
+		for message := range messages {
+			for sent := false; !sent; {
+				select {
+				case forwarded <- message:
+					last, sent = message, true
+				case reply := <-lastSent:
+					reply <- last
+				}
+			}
+		}
+		close(forwarded)
+		for reply := range lastSent {
+			reply <- last
+		}
+	}()
+	for !d.handleChan(forwarded, lastSent) {
+	}
+}
+
+// handleChan calls the wrapped Demux HandleChan and returns true if it
+// returns, or false if it panics.
+func (d *recoverDemux) handleChan(messages <-chan interface{}, lastSent chan chan interface{}) (returned bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			reply := make(chan interface{})
+			lastSent <- reply
+			if message := <-reply; d.onPanic != nil {
+				d.onPanic(message, recovered)
+			}
+		}
+	}()
+	d.demux.HandleChan(messages)
+	return true
+}
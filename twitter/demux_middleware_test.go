@@ -0,0 +1,179 @@
+package twitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainDemux_Order(t *testing.T) {
+	var calls []string
+	recordingMiddleware := func(name string) DemuxMiddleware {
+		return FilterDemux(func(message interface{}) bool {
+			calls = append(calls, name)
+			return true
+		})
+	}
+	counts := &counter{}
+	demux := ChainDemux(newCounterDemux(counts), recordingMiddleware("first"), recordingMiddleware("second"))
+	demux.Handle(&Tweet{})
+	assert.Equal(t, []string{"first", "second"}, calls)
+	assert.Equal(t, &counter{all: 1, tweet: 1}, counts)
+}
+
+func TestChainDemux_KeepsOtherMessages(t *testing.T) {
+	messages, expectedCounts := exampleMessages()
+	counts := &counter{}
+	demux := ChainDemux(newCounterDemux(counts),
+		DropRetweets(),
+		FilterLanguages("en"),
+		FilterMinFollowers(0),
+		DedupeByID(10),
+		SampleTweets(1),
+		RecoverPanics(nil),
+	)
+	// exampleMessages Tweet has no lang or user, so only it is dropped
+	expectedCounts.all--
+	expectedCounts.tweet--
+	ch := make(chan interface{})
+	go func() {
+		for _, msg := range messages {
+			ch <- msg
+		}
+		close(ch)
+	}()
+	demux.HandleChan(ch)
+	assert.Equal(t, expectedCounts, counts)
+}
+
+func TestDropRetweets(t *testing.T) {
+	counts := &counter{}
+	demux := DropRetweets()(newCounterDemux(counts))
+	demux.Handle(&Tweet{ID: 1})
+	demux.Handle(&Tweet{ID: 2, RetweetedStatus: &Tweet{ID: 1}})
+	demux.Handle(&Tweet{ID: 3, QuotedStatus: &Tweet{ID: 1}})
+	assert.Equal(t, &counter{all: 2, tweet: 2}, counts)
+}
+
+func TestFilterLanguages(t *testing.T) {
+	counts := &counter{}
+	demux := FilterLanguages("en", "FR")(newCounterDemux(counts))
+	demux.Handle(&Tweet{Lang: "en"})
+	demux.Handle(&Tweet{Lang: "fr"})
+	demux.Handle(&Tweet{Lang: "de"})
+	demux.Handle(&StatusDeletion{})
+	assert.Equal(t, &counter{all: 3, tweet: 2, statusDeletion: 1}, counts)
+}
+
+func TestFilterMinFollowers(t *testing.T) {
+	counts := &counter{}
+	demux := FilterMinFollowers(100)(newCounterDemux(counts))
+	demux.Handle(&Tweet{User: &User{FollowersCount: 100}})
+	demux.Handle(&Tweet{User: &User{FollowersCount: 99}})
+	demux.Handle(&Tweet{})
+	assert.Equal(t, &counter{all: 1, tweet: 1}, counts)
+}
+
+func TestDedupeByID(t *testing.T) {
+	counts := &counter{}
+	demux := DedupeByID(2)(newCounterDemux(counts))
+	for _, id := range []int64{1, 2, 1, 2, 3, 1} {
+		demux.Handle(&Tweet{ID: id})
+	}
+	demux.Handle(&DirectMessage{ID: 4})
+	demux.Handle(&DirectMessage{ID: 4})
+	demux.Handle(&StreamLimit{})
+	demux.Handle(&StreamLimit{})
+	// 1 is forgotten once 2 and 3 have been seen
+	assert.Equal(t, &counter{all: 7, tweet: 4, dm: 1, streamLimit: 2}, counts)
+
+	// the oldest ID is forgotten as the window wraps around
+	counts = &counter{}
+	demux = DedupeByID(3)(newCounterDemux(counts))
+	for _, id := range []int64{1, 2, 3, 4, 5, 3, 4, 5, 2, 6, 7, 8, 2} {
+		demux.Handle(&Tweet{ID: id})
+	}
+	assert.Equal(t, &counter{all: 10, tweet: 10}, counts)
+}
+
+func TestSampleTweets(t *testing.T) {
+	cases := []struct {
+		rate    float64
+		min     int
+		max     int
+		limited int
+	}{
+		{0, 0, 0, 1},
+		{0.5, 400, 600, 1},
+		{1, 1000, 1000, 1},
+	}
+	for _, c := range cases {
+		counts := &counter{}
+		demux := SampleTweets(c.rate)(newCounterDemux(counts))
+		for id := int64(1); id <= 1000; id++ {
+			demux.Handle(&Tweet{ID: 781760642139250689 + id})
+		}
+		demux.Handle(&StreamLimit{})
+		assert.True(t, counts.tweet >= c.min && counts.tweet <= c.max, "rate %v kept %d", c.rate, counts.tweet)
+		assert.Equal(t, c.limited, counts.streamLimit)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	counts := &counter{}
+	switchDemux := newCounterDemux(counts).(SwitchDemux)
+	switchDemux.DM = func(dm *DirectMessage) {
+		panic("handler failed")
+	}
+	var recovered []interface{}
+	demux := RecoverPanics(func(message, r interface{}) {
+		recovered = append(recovered, message, r)
+	})(switchDemux)
+	dm := &DirectMessage{}
+	ch := make(chan interface{}, 3)
+	ch <- &Tweet{}
+	ch <- dm
+	ch <- &Tweet{}
+	close(ch)
+	demux.HandleChan(ch)
+	assert.Equal(t, []interface{}{dm, "handler failed"}, recovered)
+	assert.Equal(t, &counter{all: 3, tweet: 2}, counts)
+}
+
+func TestRecoverPanics_WorkerDemux(t *testing.T) {
+	messages, expectedCounts := exampleMessages()
+	counts := &counter{}
+	demux := RecoverPanics(nil)(NewWorkerDemux(newCounterDemux(counts), 1, 0, nil))
+	ch := make(chan interface{})
+	go func() {
+		for _, msg := range messages {
+			ch <- msg
+		}
+		close(ch)
+	}()
+	// returns once the WorkerDemux has drained
+	demux.HandleChan(ch)
+	assert.Equal(t, expectedCounts, counts)
+}
+
+func TestFilterDemux_WorkerDemux(t *testing.T) {
+	messages, expectedCounts := exampleMessages()
+	counts := &counter{}
+	workers := NewWorkerDemux(newCounterDemux(counts), 1, 0, nil)
+	demux := FilterDemux(func(message interface{}) bool {
+		_, ok := message.(*Tweet)
+		return !ok
+	})(workers)
+	expectedCounts.all--
+	expectedCounts.tweet--
+	ch := make(chan interface{})
+	go func() {
+		for _, msg := range messages {
+			ch <- msg
+		}
+		close(ch)
+	}()
+	// returns once the WorkerDemux has drained
+	demux.HandleChan(ch)
+	assert.Equal(t, expectedCounts, counts)
+}
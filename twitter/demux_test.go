@@ -133,3 +133,77 @@ func exampleMessages() (messages []interface{}, expectedCounts *counter) {
 	}
 	return messages, expectedCounts
 }
+
+func TestEventDemux_Handle(t *testing.T) {
+	source := &User{ScreenName: "dghubble"}
+	target := &User{ScreenName: "golang"}
+	tweet := &Tweet{ID: 20}
+	list := &List{ID: 574}
+	var calls []string
+	demux := EventDemux{
+		Favorite: func(s, tg *User, tw *Tweet) {
+			assert.Equal(t, source, s)
+			assert.Equal(t, target, tg)
+			assert.Equal(t, tweet, tw)
+			calls = append(calls, "favorite")
+		},
+		QuotedTweet: func(s, tg *User, tw *Tweet) {
+			assert.Equal(t, tweet, tw)
+			calls = append(calls, "quoted_tweet")
+		},
+		Follow: func(s, tg *User) {
+			assert.Equal(t, source, s)
+			assert.Equal(t, target, tg)
+			calls = append(calls, "follow")
+		},
+		UserUpdate: func(user *User) {
+			assert.Equal(t, source, user)
+			calls = append(calls, "user_update")
+		},
+		ListCreated: func(s *User, l *List) {
+			assert.Equal(t, list, l)
+			calls = append(calls, "list_created")
+		},
+		ListMemberAdded: func(s, tg *User, l *List) {
+			assert.Equal(t, target, tg)
+			assert.Equal(t, list, l)
+			calls = append(calls, "list_member_added")
+		},
+		Other: func(event *Event) {
+			calls = append(calls, "other:"+event.Event)
+		},
+	}
+	events := []*Event{
+		{Event: FavoriteEvent, Source: source, Target: target, TargetObject: tweet},
+		{Event: QuotedTweetEvent, Source: source, Target: target, TargetObject: tweet},
+		{Event: FollowEvent, Source: source, Target: target},
+		{Event: UserUpdateEvent, Source: source},
+		{Event: ListCreatedEvent, Source: source, TargetObject: list},
+		{Event: ListMemberAddedEvent, Source: source, Target: target, TargetObject: list},
+		// no handler set
+		{Event: UnfollowEvent, Source: source, Target: target},
+		{Event: "new_event_type"},
+	}
+	for _, event := range events {
+		demux.Handle(event)
+	}
+	expected := []string{"favorite", "quoted_tweet", "follow", "user_update",
+		"list_created", "list_member_added", "other:unfollow", "other:new_event_type"}
+	assert.Equal(t, expected, calls)
+}
+
+func TestEventDemux_SwitchDemux(t *testing.T) {
+	counts := &counter{}
+	demux := newCounterDemux(counts).(SwitchDemux)
+	blocks := 0
+	demux.Event = EventDemux{
+		Block: func(source, target *User) {
+			blocks++
+		},
+		Other: demux.Event,
+	}.Handle
+	demux.Handle(&Event{Event: BlockEvent})
+	demux.Handle(&Event{Event: MuteEvent})
+	assert.Equal(t, 1, blocks)
+	assert.Equal(t, &counter{all: 2, event: 1}, counts)
+}
@@ -0,0 +1,110 @@
+package twitter
+
+import (
+	"sync"
+)
+
+// WorkerDemux is a Demux which passes messages to another Demux from a number
+// of worker goroutines, so a slow handler does not hold up every message.
+// Messages with the same key are always handled by the same worker, in the
+// order they were received.
+//
+// The client must Close() the WorkerDemux when finished, which waits until
+// queued messages have been handled. HandleChan closes it automatically.
+type WorkerDemux struct {
+	demux  Demux
+	key    func(message interface{}) int64
+	queues []chan interface{}
+	group  *sync.WaitGroup
+	once   sync.Once
+}
+
+// NewWorkerDemux returns a WorkerDemux which handles messages with the given
+// Demux from the given number of workers. Each worker queues up to queueSize
+// messages before Handle blocks. Messages are ordered by the given key func,
+// or by UserKey if it is nil.
+func NewWorkerDemux(demux Demux, workers, queueSize int, key func(message interface{}) int64) *WorkerDemux {
+	if workers < 1 {
+		workers = 1
+	}
+	if key == nil {
+		key = UserKey
+	}
+	d := &WorkerDemux{
+		demux:  demux,
+		key:    key,
+		queues: make([]chan interface{}, workers),
+		group:  &sync.WaitGroup{},
+	}
+	for i := range d.queues {
+		d.queues[i] = make(chan interface{}, queueSize)
+		d.group.Add(1)
+		go d.work(d.queues[i])
+	}
+	return d
+}
+
+// Handle queues the message for the worker which handles its key, blocking
+// while that worker's queue is full. Handle must not be called after Close.
+func (d *WorkerDemux) Handle(message interface{}) {
+	// convert to uint64 so negative keys, even math.MinInt64, pick a queue
+	key := uint64(d.key(message))
+	d.queues[key%uint64(len(d.queues))] <- message
+}
+
+// HandleChan queues messages until the channel is closed, then closes the
+// WorkerDemux and waits until all messages have been handled.
+func (d *WorkerDemux) HandleChan(messages <-chan interface{}) {
+	for message := range messages {
+		d.Handle(message)
+	}
+	d.Close()
+}
+
+// Close stops the workers once they have handled their queued messages and
+// blocks until done. Close may be called more than once.
+func (d *WorkerDemux) Close() {
+	d.once.Do(func() {
+		for _, queue := range d.queues {
+			close(queue)
+		}
+	})
+	d.group.Wait()
+}
+
+// work handles messages from a queue until it is closed.
+func (d *WorkerDemux) work(queue <-chan interface{}) {
+	defer d.group.Done()
+	for message := range queue {
+		d.demux.Handle(message)
+	}
+}
+
+// UserKey returns the ID of the user a message is about, or 0 for messages
+// about the stream itself. Tweets and their deletion, scrub_geo, and withheld
+// notices have the same key, so a deletion never overtakes its Tweet.
+func UserKey(message interface{}) int64 {
+	switch msg := message.(type) {
+	case *Tweet:
+		if msg.User != nil {
+			return msg.User.ID
+		}
+	case *DirectMessage:
+		return msg.SenderID
+	case *StatusDeletion:
+		return msg.UserID
+	case *LocationDeletion:
+		return msg.UserID
+	case *StatusWithheld:
+		return msg.UserID
+	case *UserWithheld:
+		return msg.ID
+	case *Event:
+		if msg.Source != nil {
+			return msg.Source.ID
+		}
+	case *SiteStreamMessage:
+		return msg.ForUser
+	}
+	return 0
+}
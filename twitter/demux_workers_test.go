@@ -0,0 +1,130 @@
+package twitter
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerDemux_HandleChan(t *testing.T) {
+	messages, expectedCounts := exampleMessages()
+	counts := &counter{}
+	var mu sync.Mutex
+	demux := NewWorkerDemux(lockedDemux{&mu, newCounterDemux(counts)}, 4, 1, nil)
+	ch := make(chan interface{})
+	go func() {
+		for _, msg := range messages {
+			ch <- msg
+		}
+		close(ch)
+	}()
+	// handle channel messages until exhausted and drained
+	demux.HandleChan(ch)
+	assert.Equal(t, expectedCounts, counts)
+}
+
+func TestWorkerDemux_KeyOrdering(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[int64][]int64)
+	switchDemux := NewSwitchDemux()
+	switchDemux.Tweet = func(tweet *Tweet) {
+		// slow handlers for some users must not reorder others
+		if tweet.User.ID%2 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		mu.Lock()
+		handled[tweet.User.ID] = append(handled[tweet.User.ID], tweet.ID)
+		mu.Unlock()
+	}
+	switchDemux.StatusDeletion = func(deletion *StatusDeletion) {
+		mu.Lock()
+		handled[deletion.UserID] = append(handled[deletion.UserID], -deletion.ID)
+		mu.Unlock()
+	}
+	demux := NewWorkerDemux(switchDemux, 3, 2, nil)
+	expected := make(map[int64][]int64)
+	for i := int64(1); i <= 10; i++ {
+		for userID := int64(1); userID <= 5; userID++ {
+			id := userID*100 + i
+			demux.Handle(&Tweet{ID: id, User: &User{ID: userID}})
+			expected[userID] = append(expected[userID], id)
+		}
+	}
+	demux.Handle(&StatusDeletion{ID: 210, UserID: 2})
+	expected[2] = append(expected[2], -210)
+	demux.Close()
+	assert.Equal(t, expected, handled)
+}
+
+func TestWorkerDemux_NegativeKeys(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[int64]bool)
+	switchDemux := NewSwitchDemux()
+	switchDemux.Tweet = func(tweet *Tweet) {
+		mu.Lock()
+		handled[tweet.ID] = true
+		mu.Unlock()
+	}
+	demux := NewWorkerDemux(switchDemux, 3, 1, func(message interface{}) int64 {
+		return message.(*Tweet).ID
+	})
+	expected := make(map[int64]bool)
+	for _, id := range []int64{-1, -7, math.MinInt64, math.MaxInt64} {
+		demux.Handle(&Tweet{ID: id})
+		expected[id] = true
+	}
+	demux.Close()
+	assert.Equal(t, expected, handled)
+}
+
+func TestWorkerDemux_CloseTwice(t *testing.T) {
+	demux := NewWorkerDemux(NewSwitchDemux(), 2, 1, nil)
+	ch := make(chan interface{})
+	close(ch)
+	demux.HandleChan(ch)
+	assert.NotPanics(t, demux.Close)
+}
+
+func TestUserKey(t *testing.T) {
+	cases := []struct {
+		message interface{}
+		key     int64
+	}{
+		{&Tweet{User: &User{ID: 12}}, 12},
+		{&Tweet{}, 0},
+		{&DirectMessage{SenderID: 12}, 12},
+		{&StatusDeletion{ID: 20, UserID: 12}, 12},
+		{&LocationDeletion{UserID: 12}, 12},
+		{&StatusWithheld{ID: 20, UserID: 12}, 12},
+		{&UserWithheld{ID: 12}, 12},
+		{&Event{Source: &User{ID: 12}}, 12},
+		{&SiteStreamMessage{ForUser: 12}, 12},
+		{&StreamLimit{}, 0},
+		{"unknown", 0},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.key, UserKey(c.message))
+	}
+}
+
+// lockedDemux is a Demux which handles one message at a time, for handlers
+// which are not safe for concurrent use.
+type lockedDemux struct {
+	mu    *sync.Mutex
+	demux Demux
+}
+
+func (d lockedDemux) Handle(message interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.demux.Handle(message)
+}
+
+func (d lockedDemux) HandleChan(messages <-chan interface{}) {
+	for message := range messages {
+		d.Handle(message)
+	}
+}
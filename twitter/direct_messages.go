@@ -2,6 +2,7 @@ package twitter
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/dghubble/sling"
 )
@@ -21,6 +22,11 @@ type DirectMessage struct {
 	Text                string    `json:"text"`
 }
 
+// CreatedAtTime returns the time the DirectMessage was sent.
+func (d DirectMessage) CreatedAtTime() (time.Time, error) {
+	return time.Parse(time.RubyDate, d.CreatedAt)
+}
+
 // DirectMessageService provides methods for accessing Twitter direct message
 // API endpoints.
 type DirectMessageService struct {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -108,3 +109,10 @@ func TestDirectMessageService_Destroy(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, &testDM, dm)
 }
+
+func TestDirectMessage_CreatedAtTime(t *testing.T) {
+	dm := DirectMessage{CreatedAt: "Sat Nov 21 05:19:54 +0000 2015"}
+	createdAt, err := dm.CreatedAtTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2015, 11, 21, 5, 19, 54, 0, time.UTC), createdAt.UTC())
+}
@@ -1,13 +1,19 @@
 package twitter
 
+import (
+	"sort"
+	"time"
+)
+
 // Entities represent metadata and context info parsed from Twitter components.
 // https://dev.twitter.com/overview/api/entities
-// TODO: symbols
 type Entities struct {
 	Hashtags     []HashtagEntity `json:"hashtags"`
 	Media        []MediaEntity   `json:"media"`
 	Urls         []URLEntity     `json:"urls"`
 	UserMentions []MentionEntity `json:"user_mentions"`
+	Symbols      []SymbolEntity  `json:"symbols"`
+	Polls        []PollEntity    `json:"polls"`
 }
 
 // HashtagEntity represents a hashtag which has been parsed from text.
@@ -16,6 +22,32 @@ type HashtagEntity struct {
 	Text    string  `json:"text"`
 }
 
+// SymbolEntity represents a cashtag symbol, such as $TWTR, which has been
+// parsed from text. Text excludes the leading "$".
+type SymbolEntity struct {
+	Indices Indices `json:"indices"`
+	Text    string  `json:"text"`
+}
+
+// PollEntity represents a poll attached to a Tweet.
+// https://dev.twitter.com/overview/api/entities-in-twitter-objects#polls
+type PollEntity struct {
+	Options         []PollOption `json:"options"`
+	EndDatetime     string       `json:"end_datetime"`
+	DurationMinutes int          `json:"duration_minutes"`
+}
+
+// EndTime returns the time the poll ends.
+func (p PollEntity) EndTime() (time.Time, error) {
+	return time.Parse(time.RubyDate, p.EndDatetime)
+}
+
+// PollOption is a choice in a poll, numbered by Position from 1.
+type PollOption struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
 // URLEntity represents a URL which has been parsed from text.
 type URLEntity struct {
 	Indices     Indices `json:"indices"`
@@ -36,6 +68,18 @@ type MediaEntity struct {
 	Type              string     `json:"type"`
 	Sizes             MediaSizes `json:"sizes"`
 	VideoInfo         VideoInfo  `json:"video_info"`
+	// AdditionalMediaInfo is set on some videos, such as those from
+	// partners.
+	AdditionalMediaInfo *AdditionalMediaInfo `json:"additional_media_info"`
+}
+
+// AdditionalMediaInfo describes media, such as video from a media partner.
+type AdditionalMediaInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Embeddable  bool   `json:"embeddable"`
+	Monetizable bool   `json:"monetizable"`
+	SourceUser  *User  `json:"source_user"`
 }
 
 // MentionEntity represents Twitter user mentions parsed from text.
@@ -102,3 +146,36 @@ type VideoVariant struct {
 	Bitrate     int    `json:"bitrate"`
 	URL         string `json:"url"`
 }
+
+// mp4ContentType is the content type of MP4 video variants.
+const mp4ContentType = "video/mp4"
+
+// MP4Variants returns the MP4 variants, highest bitrate first. Other
+// variants, such as HLS playlists, are excluded.
+func (v VideoInfo) MP4Variants() []VideoVariant {
+	var variants []VideoVariant
+	for _, variant := range v.Variants {
+		if variant.ContentType == mp4ContentType {
+			variants = append(variants, variant)
+		}
+	}
+	sort.Stable(variantsByBitrate(variants))
+	return variants
+}
+
+// HighestBitrateMP4 returns the MP4 variant with the highest bitrate, and
+// false if there are no MP4 variants.
+func (v VideoInfo) HighestBitrateMP4() (VideoVariant, bool) {
+	variants := v.MP4Variants()
+	if len(variants) == 0 {
+		return VideoVariant{}, false
+	}
+	return variants[0], true
+}
+
+// variantsByBitrate sorts VideoVariants by Bitrate, highest first.
+type variantsByBitrate []VideoVariant
+
+func (v variantsByBitrate) Len() int           { return len(v) }
+func (v variantsByBitrate) Less(i, j int) bool { return v[i].Bitrate > v[j].Bitrate }
+func (v variantsByBitrate) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
@@ -1,7 +1,9 @@
 package twitter
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +22,66 @@ func TestIndices(t *testing.T) {
 		assert.Equal(t, c.expectedEnd, c.pair.End())
 	}
 }
+
+func TestEntities_decode(t *testing.T) {
+	data := `{
+		"hashtags": [],
+		"urls": [],
+		"user_mentions": [],
+		"symbols": [{"indices": [8, 13], "text": "TWTR"}],
+		"polls": [{
+			"options": [{"position": 1, "text": "Yes"}, {"position": 2, "text": "No"}],
+			"end_datetime": "Thu May 25 22:20:27 +0000 2017",
+			"duration_minutes": 60
+		}],
+		"media": [{
+			"id": 861627472244162561,
+			"type": "video",
+			"additional_media_info": {
+				"title": "Highlights",
+				"description": "The best plays",
+				"embeddable": true,
+				"monetizable": false,
+				"source_user": {"id": 6253282, "screen_name": "TwitterAPI"}
+			}
+		}]
+	}`
+	entities := new(Entities)
+	err := json.Unmarshal([]byte(data), entities)
+	assert.Nil(t, err)
+	assert.Equal(t, []SymbolEntity{{Indices: Indices{8, 13}, Text: "TWTR"}}, entities.Symbols)
+	expectedPoll := PollEntity{
+		Options:         []PollOption{{Position: 1, Text: "Yes"}, {Position: 2, Text: "No"}},
+		EndDatetime:     "Thu May 25 22:20:27 +0000 2017",
+		DurationMinutes: 60,
+	}
+	assert.Equal(t, []PollEntity{expectedPoll}, entities.Polls)
+	endTime, err := entities.Polls[0].EndTime()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, 5, 25, 22, 20, 27, 0, time.UTC), endTime.UTC())
+	expectedInfo := &AdditionalMediaInfo{
+		Title:       "Highlights",
+		Description: "The best plays",
+		Embeddable:  true,
+		SourceUser:  &User{ID: 6253282, ScreenName: "TwitterAPI"},
+	}
+	if assert.Len(t, entities.Media, 1) {
+		assert.Equal(t, expectedInfo, entities.Media[0].AdditionalMediaInfo)
+	}
+}
+
+func TestVideoInfo_MP4Variants(t *testing.T) {
+	low := VideoVariant{ContentType: "video/mp4", Bitrate: 320000, URL: "https://video.twimg.com/320x180/a.mp4"}
+	high := VideoVariant{ContentType: "video/mp4", Bitrate: 2176000, URL: "https://video.twimg.com/1280x720/a.mp4"}
+	medium := VideoVariant{ContentType: "video/mp4", Bitrate: 832000, URL: "https://video.twimg.com/640x360/a.mp4"}
+	playlist := VideoVariant{ContentType: "application/x-mpegURL", URL: "https://video.twimg.com/a.m3u8"}
+	info := VideoInfo{Variants: []VideoVariant{low, playlist, high, medium}}
+	assert.Equal(t, []VideoVariant{high, medium, low}, info.MP4Variants())
+	variant, ok := info.HighestBitrateMP4()
+	assert.True(t, ok)
+	assert.Equal(t, high, variant)
+
+	variant, ok = VideoInfo{Variants: []VideoVariant{playlist}}.HighestBitrateMP4()
+	assert.False(t, ok)
+	assert.Equal(t, VideoVariant{}, variant)
+}
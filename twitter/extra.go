@@ -0,0 +1,156 @@
+package twitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Known JSON fields of types which keep unknown fields in Extra.
+var (
+	tweetFields = newStructFields(reflect.TypeOf(Tweet{}))
+	userFields  = newStructFields(reflect.TypeOf(User{}))
+)
+
+// UnmarshalJSON decodes a Tweet, keeping fields it does not know in Extra.
+func (t *Tweet) UnmarshalJSON(data []byte) error {
+	type tweet Tweet
+	extra, err := unmarshalFields(data, reflect.ValueOf((*tweet)(t)).Elem(), tweetFields)
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Tweet, including the fields in Extra.
+func (t Tweet) MarshalJSON() ([]byte, error) {
+	type tweet Tweet
+	data, err := json.Marshal(tweet(t))
+	if err != nil {
+		return nil, err
+	}
+	return appendFields(data, t.Extra, tweetFields)
+}
+
+// UnmarshalJSON decodes a User, keeping fields it does not know in Extra.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	extra, err := unmarshalFields(data, reflect.ValueOf((*user)(u)).Elem(), userFields)
+	u.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a User, including the fields in Extra.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	data, err := json.Marshal(user(u))
+	if err != nil {
+		return nil, err
+	}
+	return appendFields(data, u.Extra, userFields)
+}
+
+// structFields are the indices of the JSON fields of a struct type by name,
+// and by lower case name for keys which match a name case insensitively, as
+// encoding/json matches them.
+type structFields struct {
+	byName       map[string]int
+	byFoldedName map[string]int
+}
+
+// newStructFields returns the JSON fields of a struct type.
+func newStructFields(t reflect.Type) structFields {
+	fields := structFields{byName: make(map[string]int), byFoldedName: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		fields.byName[name] = i
+		if _, ok := fields.byFoldedName[strings.ToLower(name)]; !ok {
+			fields.byFoldedName[strings.ToLower(name)] = i
+		}
+	}
+	return fields
+}
+
+// known returns true if the name is a JSON field.
+func (f structFields) known(name string) bool {
+	_, ok := f.byName[name]
+	return ok
+}
+
+// index returns the index of the field a JSON object key decodes into.
+func (f structFields) index(key string) (int, bool) {
+	if i, ok := f.byName[key]; ok {
+		return i, true
+	}
+	i, ok := f.byFoldedName[strings.ToLower(key)]
+	return i, ok
+}
+
+// unmarshalFields decodes a JSON object into the fields of a struct value,
+// decoding the object once, and returns the fields which are not known, or
+// nil if there are none.
+func unmarshalFields(data []byte, v reflect.Value, fields structFields) (map[string]json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for key, value := range object {
+		i, ok := fields.index(key)
+		if !ok {
+			if extra == nil {
+				extra = make(map[string]json.RawMessage)
+			}
+			extra[key] = value
+			continue
+		}
+		if err := json.Unmarshal(value, v.Field(i).Addr().Interface()); err != nil {
+			return extra, err
+		}
+	}
+	return extra, nil
+}
+
+// appendFields appends the extra fields, except known fields, to an encoded
+// JSON object, in name order.
+func appendFields(data []byte, extra map[string]json.RawMessage, fields structFields) ([]byte, error) {
+	var names []string
+	for name := range extra {
+		if !fields.known(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return data, nil
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	// data is an object, "{...}"
+	buf.Write(data[:len(data)-1])
+	for i, name := range names {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value := extra[name]
+		if len(value) == 0 {
+			value = json.RawMessage("null")
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
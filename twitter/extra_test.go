@@ -0,0 +1,114 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const extraTweetJSON = `{
+	"id": 850006245121695744,
+	"text": "1/ Today we’re sharing our vision for the future of the Twitter API platform!",
+	"geo": null,
+	"reply_count": 12,
+	"quoted_status_permalink": {"url": "https://t.co/abc", "expanded": "https://twitter.com/TwitterDev/status/1", "display": "twitter.com/TwitterDev/st…"},
+	"user": {"id": 2244994945, "screen_name": "TwitterDev", "profile_location": {"name": "Internet"}},
+	"retweeted_status": {"id": 1, "text": "retweeted", "edit_history": [1]}
+}`
+
+// assertJSONSubset asserts that the expected JSON values are in the actual
+// JSON values.
+func assertJSONSubset(t *testing.T, expected, actual interface{}, path string) {
+	expectedObject, ok := expected.(map[string]interface{})
+	if !ok {
+		assert.Equal(t, expected, actual, path)
+		return
+	}
+	actualObject, ok := actual.(map[string]interface{})
+	if !assert.True(t, ok, path) {
+		return
+	}
+	for key, value := range expectedObject {
+		assertJSONSubset(t, value, actualObject[key], path+"."+key)
+	}
+}
+
+func TestTweet_JSONRoundTrip(t *testing.T) {
+	tweet := new(Tweet)
+	err := json.Unmarshal([]byte(extraTweetJSON), tweet)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(850006245121695744), tweet.ID)
+	assert.Equal(t, map[string]json.RawMessage{
+		"geo":                     json.RawMessage(`null`),
+		"reply_count":             json.RawMessage(`12`),
+		"quoted_status_permalink": json.RawMessage(`{"url": "https://t.co/abc", "expanded": "https://twitter.com/TwitterDev/status/1", "display": "twitter.com/TwitterDev/st…"}`),
+	}, tweet.Extra)
+	assert.Equal(t, map[string]json.RawMessage{"profile_location": json.RawMessage(`{"name": "Internet"}`)}, tweet.User.Extra)
+	assert.Equal(t, map[string]json.RawMessage{"edit_history": json.RawMessage(`[1]`)}, tweet.RetweetedStatus.Extra)
+
+	data, err := json.Marshal(tweet)
+	assert.Nil(t, err)
+	var expected, actual interface{}
+	assert.Nil(t, json.Unmarshal([]byte(extraTweetJSON), &expected))
+	assert.Nil(t, json.Unmarshal(data, &actual))
+	assertJSONSubset(t, expected, actual, "tweet")
+
+	// decoding the encoded Tweet gives the same Tweet
+	decoded := new(Tweet)
+	assert.Nil(t, json.Unmarshal(data, decoded))
+	assert.Len(t, decoded.Extra, len(tweet.Extra))
+	for name, value := range tweet.Extra {
+		assert.JSONEq(t, string(value), string(decoded.Extra[name]), name)
+	}
+	assert.Equal(t, tweet.Text, decoded.Text)
+}
+
+func TestTweet_JSONNoExtra(t *testing.T) {
+	tweet := new(Tweet)
+	err := json.Unmarshal([]byte(`{"id": 1, "text": "hello"}`), tweet)
+	assert.Nil(t, err)
+	assert.Equal(t, &Tweet{ID: 1, Text: "hello"}, tweet)
+
+	// only known fields are encoded
+	data, err := json.Marshal(Tweet{ID: 1})
+	assert.Nil(t, err)
+	var fields map[string]json.RawMessage
+	assert.Nil(t, json.Unmarshal(data, &fields))
+	for name := range fields {
+		assert.True(t, tweetFields.known(name), name)
+	}
+	assert.Equal(t, json.RawMessage(`1`), fields["id"])
+}
+
+func TestUser_JSONExtraDoesNotOverrideFields(t *testing.T) {
+	user := User{
+		ID: 2244994945,
+		Extra: map[string]json.RawMessage{
+			"id":               json.RawMessage(`1`),
+			"profile_location": json.RawMessage(`null`),
+		},
+	}
+	data, err := json.Marshal(user)
+	assert.Nil(t, err)
+	var fields map[string]json.RawMessage
+	assert.Nil(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, json.RawMessage(`2244994945`), fields["id"])
+	assert.Equal(t, json.RawMessage(`null`), fields["profile_location"])
+}
+
+func TestTweet_UnmarshalJSONError(t *testing.T) {
+	tweet := new(Tweet)
+	err := json.Unmarshal([]byte(`{"id": "not a number"}`), tweet)
+	assert.NotNil(t, err)
+	err = json.Unmarshal([]byte(`[]`), tweet)
+	assert.NotNil(t, err)
+}
+
+func TestTweet_UnmarshalJSONFoldedNames(t *testing.T) {
+	// keys match fields case insensitively, as with encoding/json
+	tweet := new(Tweet)
+	err := json.Unmarshal([]byte(`{"ID": 1, "Text": "hello", "User": {"Screen_Name": "golang"}}`), tweet)
+	assert.Nil(t, err)
+	assert.Equal(t, &Tweet{ID: 1, Text: "hello", User: &User{ScreenName: "golang"}}, tweet)
+}
@@ -0,0 +1,293 @@
+package twitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Point is a location. Twitter and GeoJSON coordinates list the longitude
+// before the latitude.
+type Point struct {
+	Lng float64
+	Lat float64
+}
+
+// Coordinates returns the Point as GeoJSON Point Coordinates.
+func (p Point) Coordinates() *Coordinates {
+	return &Coordinates{Type: "Point", Coordinates: [2]float64{p.Lng, p.Lat}}
+}
+
+// valid returns true if the longitude and latitude are in range.
+func (p Point) valid() bool {
+	return p.Lng >= -180 && p.Lng <= 180 && p.Lat >= -90 && p.Lat <= 90
+}
+
+// Bounds is a rectangle from its south west corner to its north east corner.
+type Bounds struct {
+	SW Point
+	NE Point
+}
+
+// NewBounds returns the Bounds with the given corners, or an error if the
+// corners are out of range or SW is not south west of NE.
+func NewBounds(sw, ne Point) (Bounds, error) {
+	b := Bounds{SW: sw, NE: ne}
+	return b, b.Validate()
+}
+
+// Validate returns an error if the corners of the Bounds are out of range or
+// SW is not south west of NE, as required by StreamFilterParams.Locations.
+// Bounds which cross the antimeridian are not supported.
+func (b Bounds) Validate() error {
+	if !b.SW.valid() || !b.NE.valid() {
+		return fmt.Errorf("twitter: bounds %s are out of range", strings.Join(b.Locations(), ","))
+	}
+	if b.SW.Lng > b.NE.Lng || b.SW.Lat > b.NE.Lat {
+		return fmt.Errorf("twitter: bounds %s must list the south west corner before the north east corner", strings.Join(b.Locations(), ","))
+	}
+	return nil
+}
+
+// Contains returns true if the Point is within the Bounds, including its
+// edges.
+func (b Bounds) Contains(p Point) bool {
+	return p.Lng >= b.SW.Lng && p.Lng <= b.NE.Lng && p.Lat >= b.SW.Lat && p.Lat <= b.NE.Lat
+}
+
+// Intersects returns true if the Bounds overlap or touch.
+func (b Bounds) Intersects(other Bounds) bool {
+	return other.SW.Lng <= b.NE.Lng && other.NE.Lng >= b.SW.Lng && other.SW.Lat <= b.NE.Lat && other.NE.Lat >= b.SW.Lat
+}
+
+// extend grows the Bounds to contain the Point.
+func (b *Bounds) extend(p Point) {
+	b.SW.Lng, b.NE.Lng = math.Min(b.SW.Lng, p.Lng), math.Max(b.NE.Lng, p.Lng)
+	b.SW.Lat, b.NE.Lat = math.Min(b.SW.Lat, p.Lat), math.Max(b.NE.Lat, p.Lat)
+}
+
+// Center returns the center of the Bounds.
+func (b Bounds) Center() Point {
+	return Point{Lng: (b.SW.Lng + b.NE.Lng) / 2, Lat: (b.SW.Lat + b.NE.Lat) / 2}
+}
+
+// Locations returns the Bounds as StreamFilterParams.Locations values,
+// south west longitude and latitude then north east longitude and latitude.
+func (b Bounds) Locations() []string {
+	return []string{formatCoordinate(b.SW.Lng), formatCoordinate(b.SW.Lat), formatCoordinate(b.NE.Lng), formatCoordinate(b.NE.Lat)}
+}
+
+// BoundingBox returns the Bounds as a GeoJSON Polygon BoundingBox, with the
+// corners listed counterclockwise from the south west corner.
+func (b Bounds) BoundingBox() *BoundingBox {
+	return &BoundingBox{
+		Type: "Polygon",
+		Coordinates: [][][2]float64{{
+			{b.SW.Lng, b.SW.Lat},
+			{b.NE.Lng, b.SW.Lat},
+			{b.NE.Lng, b.NE.Lat},
+			{b.SW.Lng, b.NE.Lat},
+			{b.SW.Lng, b.SW.Lat},
+		}},
+	}
+}
+
+// FilterLocations returns StreamFilterParams.Locations for the Bounds, or an
+// error if any Bounds is invalid.
+func FilterLocations(bounds ...Bounds) ([]string, error) {
+	var locations []string
+	for _, b := range bounds {
+		if err := b.Validate(); err != nil {
+			return nil, err
+		}
+		locations = append(locations, b.Locations()...)
+	}
+	return locations, nil
+}
+
+func formatCoordinate(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Point returns the Coordinates as a Point.
+func (c Coordinates) Point() Point {
+	return Point{Lng: c.Coordinates[0], Lat: c.Coordinates[1]}
+}
+
+// Bounds returns the smallest Bounds containing the BoundingBox, and false
+// if it has no coordinates.
+func (b BoundingBox) Bounds() (Bounds, bool) {
+	var bounds Bounds
+	found := false
+	for _, ring := range b.Coordinates {
+		for _, coordinates := range ring {
+			p := Point{Lng: coordinates[0], Lat: coordinates[1]}
+			if !found {
+				bounds = Bounds{SW: p, NE: p}
+				found = true
+			}
+			bounds.extend(p)
+		}
+	}
+	return bounds, found
+}
+
+// Contains returns true if the Point is within the Bounds of the
+// BoundingBox.
+func (b BoundingBox) Contains(p Point) bool {
+	bounds, ok := b.Bounds()
+	return ok && bounds.Contains(p)
+}
+
+// Centroid returns the centroid of the outer ring of the BoundingBox, and
+// false if it has no coordinates. The centroid of a ring with no area is the
+// center of its Bounds.
+func (b BoundingBox) Centroid() (Point, bool) {
+	bounds, ok := b.Bounds()
+	if !ok {
+		return Point{}, false
+	}
+	// planar polygon centroid, which is accurate for Place sized areas
+	ring := b.Coordinates[0]
+	var area, lng, lat float64
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		cross := p[0]*q[1] - q[0]*p[1]
+		area += cross
+		lng += (p[0] + q[0]) * cross
+		lat += (p[1] + q[1]) * cross
+	}
+	if area == 0 {
+		return bounds.Center(), true
+	}
+	return Point{Lng: lng / (3 * area), Lat: lat / (3 * area)}, true
+}
+
+// Centroid returns the centroid of the Place's Geometry, or of its
+// BoundingBox, and false if it has neither.
+func (p Place) Centroid() (Point, bool) {
+	if p.Geometry != nil {
+		if centroid, ok := p.Geometry.Centroid(); ok {
+			return centroid, true
+		}
+	}
+	if p.BoundingBox != nil {
+		return p.BoundingBox.Centroid()
+	}
+	return Point{}, false
+}
+
+// GeoJSONFeature is a GeoJSON Feature. Geometry is a *Coordinates Point or a
+// *BoundingBox Polygon, which encode as GeoJSON geometries.
+// https://tools.ietf.org/html/rfc7946
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   interface{}            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSON returns the Tweet as a GeoJSON Feature, with the Tweet's exact
+// Coordinates or else its Place's BoundingBox as the geometry, and false if
+// the Tweet has no location.
+func (t Tweet) GeoJSON() (*GeoJSONFeature, bool) {
+	feature := &GeoJSONFeature{
+		Type: "Feature",
+		Properties: map[string]interface{}{
+			"id_str": t.IDStr,
+			"text":   t.FullTextOrText(),
+		},
+	}
+	if t.Place != nil {
+		feature.Properties["place"] = t.Place.FullName
+	}
+	switch {
+	case t.Coordinates != nil:
+		feature.Geometry = t.Coordinates.Point().Coordinates()
+	case t.Place != nil && t.Place.BoundingBox != nil:
+		feature.Geometry = t.Place.BoundingBox
+	default:
+		return nil, false
+	}
+	return feature, true
+}
+
+// geoJSONObject is a GeoJSON geometry, Feature, or FeatureCollection.
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+	Geometries  []geoJSONObject `json:"geometries"`
+	Features    []geoJSONObject `json:"features"`
+}
+
+// ParseGeoJSONBounds returns the Bounds of a GeoJSON Point, MultiPoint,
+// LineString, Polygon, or MultiPolygon geometry, or of a Feature,
+// FeatureCollection, or GeometryCollection of them. The Bounds can be passed
+// to FilterLocations.
+func ParseGeoJSONBounds(data []byte) (Bounds, error) {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return Bounds{}, err
+	}
+	var points []Point
+	if err := object.points(&points); err != nil {
+		return Bounds{}, err
+	}
+	if len(points) == 0 {
+		return Bounds{}, fmt.Errorf("twitter: GeoJSON %s has no coordinates", object.Type)
+	}
+	bounds := Bounds{SW: points[0], NE: points[0]}
+	for _, p := range points[1:] {
+		bounds.extend(p)
+	}
+	return bounds, bounds.Validate()
+}
+
+// points appends the points of the GeoJSON object.
+func (o geoJSONObject) points(points *[]Point) error {
+	var positions [][2]float64
+	var err error
+	switch o.Type {
+	case "Point":
+		var position [2]float64
+		err = json.Unmarshal(o.Coordinates, &position)
+		positions = append(positions, position)
+	case "MultiPoint", "LineString":
+		err = json.Unmarshal(o.Coordinates, &positions)
+	case "Polygon", "MultiLineString":
+		var rings [][][2]float64
+		err = json.Unmarshal(o.Coordinates, &rings)
+		for _, ring := range rings {
+			positions = append(positions, ring...)
+		}
+	case "MultiPolygon":
+		var polygons [][][][2]float64
+		err = json.Unmarshal(o.Coordinates, &polygons)
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				positions = append(positions, ring...)
+			}
+		}
+	case "Feature":
+		if o.Geometry != nil {
+			return o.Geometry.points(points)
+		}
+	case "FeatureCollection", "GeometryCollection":
+		for _, child := range append(o.Features, o.Geometries...) {
+			if err := child.points(points); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("twitter: unsupported GeoJSON type %q", o.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("twitter: invalid GeoJSON %s coordinates: %v", o.Type, err)
+	}
+	for _, position := range positions {
+		*points = append(*points, Point{Lng: position[0], Lat: position[1]})
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sanFranciscoBounds are the Bounds of the sanFranciscoBox BoundingBox.
+var sanFranciscoBounds = Bounds{SW: Point{Lng: -122.75, Lat: 36.8}, NE: Point{Lng: -121.75, Lat: 37.8}}
+
+// sanFranciscoBox is a Place bounding box, which Twitter lists from the south
+// west corner without closing the ring.
+var sanFranciscoBox = &BoundingBox{
+	Type:        "Polygon",
+	Coordinates: [][][2]float64{{{-122.75, 36.8}, {-122.75, 37.8}, {-121.75, 37.8}, {-121.75, 36.8}}},
+}
+
+func TestNewBounds(t *testing.T) {
+	bounds, err := NewBounds(Point{Lng: -122.75, Lat: 36.8}, Point{Lng: -121.75, Lat: 37.8})
+	assert.Nil(t, err)
+	assert.Equal(t, sanFranciscoBounds, bounds)
+
+	_, err = NewBounds(Point{Lng: -121.75, Lat: 37.8}, Point{Lng: -122.75, Lat: 36.8})
+	assert.EqualError(t, err, "twitter: bounds -121.75,37.8,-122.75,36.8 must list the south west corner before the north east corner")
+	// latitude and longitude swapped
+	_, err = NewBounds(Point{Lng: 36.8, Lat: -122.75}, Point{Lng: 37.8, Lat: -121.75})
+	assert.EqualError(t, err, "twitter: bounds 36.8,-122.75,37.8,-121.75 are out of range")
+}
+
+func TestBounds(t *testing.T) {
+	assert.True(t, sanFranciscoBounds.Contains(Point{Lng: -122.4, Lat: 37.7}))
+	assert.True(t, sanFranciscoBounds.Contains(Point{Lng: -122.75, Lat: 36.8}))
+	assert.False(t, sanFranciscoBounds.Contains(Point{Lng: 37.7, Lat: -122.4}))
+	assert.Equal(t, Point{Lng: -122.25, Lat: 37.3}, sanFranciscoBounds.Center())
+	assert.True(t, sanFranciscoBounds.Intersects(Bounds{SW: Point{Lng: -122, Lat: 37}, NE: Point{Lng: -121, Lat: 38}}))
+	assert.False(t, sanFranciscoBounds.Intersects(Bounds{SW: Point{Lng: -74, Lat: 40}, NE: Point{Lng: -73, Lat: 41}}))
+	assert.Equal(t, []string{"-122.75", "36.8", "-121.75", "37.8"}, sanFranciscoBounds.Locations())
+
+	box := sanFranciscoBounds.BoundingBox()
+	bounds, ok := box.Bounds()
+	assert.True(t, ok)
+	assert.Equal(t, sanFranciscoBounds, bounds)
+	assert.Len(t, box.Coordinates[0], 5)
+	assert.Equal(t, box.Coordinates[0][0], box.Coordinates[0][4])
+}
+
+func TestFilterLocations(t *testing.T) {
+	newYork := Bounds{SW: Point{Lng: -74, Lat: 40}, NE: Point{Lng: -73, Lat: 41}}
+	locations, err := FilterLocations(sanFranciscoBounds, newYork)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"-122.75", "36.8", "-121.75", "37.8", "-74", "40", "-73", "41"}, locations)
+	// the locations are valid filter params
+	_, err = NewFilterMatcher(&StreamFilterParams{Locations: locations})
+	assert.Nil(t, err)
+
+	_, err = FilterLocations(sanFranciscoBounds, Bounds{SW: newYork.NE, NE: newYork.SW})
+	assert.NotNil(t, err)
+}
+
+func TestBoundingBox(t *testing.T) {
+	bounds, ok := sanFranciscoBox.Bounds()
+	assert.True(t, ok)
+	assert.Equal(t, sanFranciscoBounds, bounds)
+	assert.True(t, sanFranciscoBox.Contains(Point{Lng: -122.4, Lat: 37.7}))
+	assert.False(t, sanFranciscoBox.Contains(Point{Lng: -73.5, Lat: 40.5}))
+	centroid, ok := sanFranciscoBox.Centroid()
+	assert.True(t, ok)
+	assert.InDelta(t, -122.25, centroid.Lng, 1e-9)
+	assert.InDelta(t, 37.3, centroid.Lat, 1e-9)
+
+	// the centroid of a triangle is the mean of its corners
+	triangle := BoundingBox{Coordinates: [][][2]float64{{{0, 0}, {3, 0}, {0, 3}, {0, 0}}}}
+	centroid, _ = triangle.Centroid()
+	assert.InDelta(t, 1, centroid.Lng, 1e-9)
+	assert.InDelta(t, 1, centroid.Lat, 1e-9)
+
+	// a point Place has no area
+	point := BoundingBox{Coordinates: [][][2]float64{{{-122.4, 37.7}, {-122.4, 37.7}, {-122.4, 37.7}, {-122.4, 37.7}}}}
+	centroid, _ = point.Centroid()
+	assert.Equal(t, Point{Lng: -122.4, Lat: 37.7}, centroid)
+
+	_, ok = BoundingBox{}.Centroid()
+	assert.False(t, ok)
+	assert.False(t, BoundingBox{}.Contains(Point{}))
+}
+
+func TestPlace_Centroid(t *testing.T) {
+	centroid, ok := Place{BoundingBox: sanFranciscoBox}.Centroid()
+	assert.True(t, ok)
+	assert.InDelta(t, -122.25, centroid.Lng, 1e-9)
+	geometry := &BoundingBox{Coordinates: [][][2]float64{{{-122.4, 37.7}}}}
+	centroid, ok = Place{BoundingBox: sanFranciscoBox, Geometry: geometry}.Centroid()
+	assert.True(t, ok)
+	assert.Equal(t, Point{Lng: -122.4, Lat: 37.7}, centroid)
+	_, ok = Place{}.Centroid()
+	assert.False(t, ok)
+}
+
+func TestCoordinates_Point(t *testing.T) {
+	coordinates := &Coordinates{Type: "Point", Coordinates: [2]float64{-122.4, 37.7}}
+	assert.Equal(t, Point{Lng: -122.4, Lat: 37.7}, coordinates.Point())
+	assert.Equal(t, coordinates, coordinates.Point().Coordinates())
+}
+
+func TestTweet_GeoJSON(t *testing.T) {
+	tweet := Tweet{
+		IDStr:       "1",
+		Text:        "hello",
+		Coordinates: &Coordinates{Type: "Point", Coordinates: [2]float64{-122.4, 37.7}},
+		Place:       &Place{FullName: "San Francisco, CA", BoundingBox: sanFranciscoBox},
+	}
+	feature, ok := tweet.GeoJSON()
+	assert.True(t, ok)
+	data, err := json.Marshal(feature)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "Feature",
+		"geometry": {"type": "Point", "coordinates": [-122.4, 37.7]},
+		"properties": {"id_str": "1", "text": "hello", "place": "San Francisco, CA"}
+	}`, string(data))
+
+	tweet.Coordinates = nil
+	feature, ok = tweet.GeoJSON()
+	assert.True(t, ok)
+	assert.Equal(t, sanFranciscoBox, feature.Geometry)
+
+	_, ok = Tweet{}.GeoJSON()
+	assert.False(t, ok)
+}
+
+func TestParseGeoJSONBounds(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected Bounds
+	}{
+		{`{"type": "Point", "coordinates": [-122.4, 37.7]}`, Bounds{SW: Point{-122.4, 37.7}, NE: Point{-122.4, 37.7}}},
+		{`{"type": "Polygon", "coordinates": [[[-122.75, 36.8], [-121.75, 36.8], [-121.75, 37.8], [-122.75, 37.8], [-122.75, 36.8]]]}`, sanFranciscoBounds},
+		{`{"type": "Feature", "properties": {}, "geometry": {"type": "LineString", "coordinates": [[-122.75, 37.8, 10], [-121.75, 36.8, 20]]}}`, sanFranciscoBounds},
+		{`{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-122.75, 36.8]}},
+			{"type": "Feature", "geometry": {"type": "MultiPolygon", "coordinates": [[[[-121.75, 37.8]]]]}}
+		]}`, sanFranciscoBounds},
+	}
+	for _, c := range cases {
+		bounds, err := ParseGeoJSONBounds([]byte(c.data))
+		assert.Nil(t, err, c.data)
+		assert.Equal(t, c.expected, bounds, c.data)
+	}
+
+	errorCases := []struct {
+		data     string
+		expected string
+	}{
+		{`{"type": "Circle"}`, `twitter: unsupported GeoJSON type "Circle"`},
+		{`{"type": "Point", "coordinates": "here"}`, "twitter: invalid GeoJSON Point coordinates: json: cannot unmarshal string into Go value of type [2]float64"},
+		{`{"type": "FeatureCollection", "features": []}`, "twitter: GeoJSON FeatureCollection has no coordinates"},
+		{`{"type": "Point", "coordinates": [37.7, -122.4]}`, "twitter: bounds 37.7,-122.4,37.7,-122.4 are out of range"},
+	}
+	for _, c := range errorCases {
+		_, err := ParseGeoJSONBounds([]byte(c.data))
+		assert.EqualError(t, err, c.expected, c.data)
+	}
+}
@@ -0,0 +1,367 @@
+package twitter
+
+import (
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// List is a curated group of Twitter users.
+// https://dev.twitter.com/rest/reference/get/lists/show
+type List struct {
+	CreatedAt       string `json:"created_at"`
+	Description     string `json:"description"`
+	Following       bool   `json:"following"`
+	FullName        string `json:"full_name"`
+	ID              int64  `json:"id"`
+	IDStr           string `json:"id_str"`
+	MemberCount     int    `json:"member_count"`
+	Mode            string `json:"mode"`
+	Name            string `json:"name"`
+	Slug            string `json:"slug"`
+	SubscriberCount int    `json:"subscriber_count"`
+	URI             string `json:"uri"`
+	User            *User  `json:"user"`
+}
+
+// List modes for the Mode param.
+const (
+	ListModePublic  = "public"
+	ListModePrivate = "private"
+)
+
+// Lists is a cursored collection of lists.
+type Lists struct {
+	Lists             []List `json:"lists"`
+	NextCursor        int64  `json:"next_cursor"`
+	NextCursorStr     string `json:"next_cursor_str"`
+	PreviousCursor    int64  `json:"previous_cursor"`
+	PreviousCursorStr string `json:"previous_cursor_str"`
+}
+
+// ListMembers is a cursored collection of list members or subscribers.
+type ListMembers struct {
+	Users             []User `json:"users"`
+	NextCursor        int64  `json:"next_cursor"`
+	NextCursorStr     string `json:"next_cursor_str"`
+	PreviousCursor    int64  `json:"previous_cursor"`
+	PreviousCursorStr string `json:"previous_cursor_str"`
+}
+
+// ListService provides methods for accessing Twitter lists API endpoints.
+type ListService struct {
+	sling *sling.Sling
+}
+
+// newListService returns a new ListService.
+func newListService(sling *sling.Sling) *ListService {
+	return &ListService{
+		sling: sling.Path("lists/"),
+	}
+}
+
+// ListListParams are the parameters for ListService.List
+type ListListParams struct {
+	UserID     int64  `url:"user_id,omitempty"`
+	ScreenName string `url:"screen_name,omitempty"`
+	Reverse    *bool  `url:"reverse,omitempty"`
+}
+
+// List returns the lists the specified user owns or subscribes to, up to 100.
+// https://dev.twitter.com/rest/reference/get/lists/list
+func (s *ListService) List(params *ListListParams) ([]List, *http.Response, error) {
+	lists := new([]List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("list.json").QueryStruct(params).Receive(lists, apiError)
+	return *lists, resp, relevantError(err, *apiError)
+}
+
+// ListShowParams are the parameters for ListService.Show, Destroy,
+// SubscribersCreate, and SubscribersDestroy. A list is specified by its
+// ListID or by its Slug and its owner's OwnerScreenName or OwnerID.
+type ListShowParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+}
+
+// Show returns the specified list.
+// https://dev.twitter.com/rest/reference/get/lists/show
+func (s *ListService) Show(params *ListShowParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("show.json").QueryStruct(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// ListStatusesParams are the parameters for ListService.Statuses
+type ListStatusesParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+	SinceID         int64  `url:"since_id,omitempty"`
+	MaxID           int64  `url:"max_id,omitempty"`
+	Count           int    `url:"count,omitempty"`
+	IncludeEntities *bool  `url:"include_entities,omitempty"`
+	IncludeRetweets *bool  `url:"include_rts,omitempty"`
+	TweetMode       string `url:"tweet_mode,omitempty"`
+}
+
+// Statuses returns a timeline of Tweets by the members of the specified list.
+// https://dev.twitter.com/rest/reference/get/lists/statuses
+func (s *ListService) Statuses(params *ListStatusesParams) ([]Tweet, *http.Response, error) {
+	tweets := new([]Tweet)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("statuses.json").QueryStruct(params).Receive(tweets, apiError)
+	return *tweets, resp, relevantError(err, *apiError)
+}
+
+// ListCreateParams are the parameters for ListService.Create
+type ListCreateParams struct {
+	Name        string `url:"name,omitempty"`
+	Mode        string `url:"mode,omitempty"`
+	Description string `url:"description,omitempty"`
+}
+
+// Create creates a new list for the authenticated user.
+// Requires a user auth context.
+// https://dev.twitter.com/rest/reference/post/lists/create
+func (s *ListService) Create(name string, params *ListCreateParams) (*List, *http.Response, error) {
+	if params == nil {
+		params = &ListCreateParams{}
+	}
+	params.Name = name
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("create.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// ListUpdateParams are the parameters for ListService.Update
+type ListUpdateParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+	Name            string `url:"name,omitempty"`
+	Mode            string `url:"mode,omitempty"`
+	Description     string `url:"description,omitempty"`
+}
+
+// Update updates the specified list. The authenticated user must own the
+// list.
+// https://dev.twitter.com/rest/reference/post/lists/update
+func (s *ListService) Update(params *ListUpdateParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("update.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// Destroy deletes the specified list. The authenticated user must own the
+// list.
+// https://dev.twitter.com/rest/reference/post/lists/destroy
+func (s *ListService) Destroy(params *ListShowParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("destroy.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// ListMembersParams are the parameters for ListService.Members and
+// Subscribers
+type ListMembersParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+	Count           int    `url:"count,omitempty"`
+	Cursor          int64  `url:"cursor,omitempty"`
+	IncludeEntities *bool  `url:"include_entities,omitempty"`
+	SkipStatus      *bool  `url:"skip_status,omitempty"`
+}
+
+// Members returns a cursored collection of the members of the specified
+// list.
+// https://dev.twitter.com/rest/reference/get/lists/members
+func (s *ListService) Members(params *ListMembersParams) (*ListMembers, *http.Response, error) {
+	members := new(ListMembers)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("members.json").QueryStruct(params).Receive(members, apiError)
+	return members, resp, relevantError(err, *apiError)
+}
+
+// ListMembersShowParams are the parameters for ListService.MembersShow and
+// SubscribersShow
+type ListMembersShowParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+	UserID          int64  `url:"user_id,omitempty"`
+	ScreenName      string `url:"screen_name,omitempty"`
+	IncludeEntities *bool  `url:"include_entities,omitempty"`
+	SkipStatus      *bool  `url:"skip_status,omitempty"`
+}
+
+// MembersShow returns the specified user if they are a member of the
+// specified list, or an error if they are not.
+// https://dev.twitter.com/rest/reference/get/lists/members/show
+func (s *ListService) MembersShow(params *ListMembersShowParams) (*User, *http.Response, error) {
+	user := new(User)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("members/show.json").QueryStruct(params).Receive(user, apiError)
+	return user, resp, relevantError(err, *apiError)
+}
+
+// ListMembersCreateParams are the parameters for ListService.MembersCreate
+// and MembersDestroy
+type ListMembersCreateParams struct {
+	ListID          int64  `url:"list_id,omitempty"`
+	Slug            string `url:"slug,omitempty"`
+	OwnerScreenName string `url:"owner_screen_name,omitempty"`
+	OwnerID         int64  `url:"owner_id,omitempty"`
+	UserID          int64  `url:"user_id,omitempty"`
+	ScreenName      string `url:"screen_name,omitempty"`
+}
+
+// MembersCreate adds the specified user to the specified list. The
+// authenticated user must own the list.
+// https://dev.twitter.com/rest/reference/post/lists/members/create
+func (s *ListService) MembersCreate(params *ListMembersCreateParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("members/create.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// MembersDestroy removes the specified user from the specified list. The
+// authenticated user must own the list.
+// https://dev.twitter.com/rest/reference/post/lists/members/destroy
+func (s *ListService) MembersDestroy(params *ListMembersCreateParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("members/destroy.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// ListMembersCreateAllParams are the parameters for
+// ListService.MembersCreateAll and MembersDestroyAll. Up to 100 users may be
+// given by UserID or ScreenName.
+type ListMembersCreateAllParams struct {
+	ListID          int64    `url:"list_id,omitempty"`
+	Slug            string   `url:"slug,omitempty"`
+	OwnerScreenName string   `url:"owner_screen_name,omitempty"`
+	OwnerID         int64    `url:"owner_id,omitempty"`
+	UserID          []int64  `url:"user_id,omitempty,comma"`
+	ScreenName      []string `url:"screen_name,omitempty,comma"`
+}
+
+// MembersCreateAll adds up to 100 users to the specified list. The
+// authenticated user must own the list.
+// https://dev.twitter.com/rest/reference/post/lists/members/create_all
+func (s *ListService) MembersCreateAll(params *ListMembersCreateAllParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("members/create_all.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// MembersDestroyAll removes up to 100 users from the specified list. The
+// authenticated user must own the list.
+// https://dev.twitter.com/rest/reference/post/lists/members/destroy_all
+func (s *ListService) MembersDestroyAll(params *ListMembersCreateAllParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("members/destroy_all.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// Subscribers returns a cursored collection of the subscribers of the
+// specified list.
+// https://dev.twitter.com/rest/reference/get/lists/subscribers
+func (s *ListService) Subscribers(params *ListMembersParams) (*ListMembers, *http.Response, error) {
+	subscribers := new(ListMembers)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("subscribers.json").QueryStruct(params).Receive(subscribers, apiError)
+	return subscribers, resp, relevantError(err, *apiError)
+}
+
+// SubscribersShow returns the specified user if they subscribe to the
+// specified list, or an error if they do not.
+// https://dev.twitter.com/rest/reference/get/lists/subscribers/show
+func (s *ListService) SubscribersShow(params *ListMembersShowParams) (*User, *http.Response, error) {
+	user := new(User)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("subscribers/show.json").QueryStruct(params).Receive(user, apiError)
+	return user, resp, relevantError(err, *apiError)
+}
+
+// SubscribersCreate subscribes the authenticated user to the specified list.
+// https://dev.twitter.com/rest/reference/post/lists/subscribers/create
+func (s *ListService) SubscribersCreate(params *ListShowParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("subscribers/create.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// SubscribersDestroy unsubscribes the authenticated user from the specified
+// list.
+// https://dev.twitter.com/rest/reference/post/lists/subscribers/destroy
+func (s *ListService) SubscribersDestroy(params *ListShowParams) (*List, *http.Response, error) {
+	list := new(List)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Post("subscribers/destroy.json").BodyForm(params).Receive(list, apiError)
+	return list, resp, relevantError(err, *apiError)
+}
+
+// ListMembershipsParams are the parameters for ListService.Memberships
+type ListMembershipsParams struct {
+	UserID             int64  `url:"user_id,omitempty"`
+	ScreenName         string `url:"screen_name,omitempty"`
+	Count              int    `url:"count,omitempty"`
+	Cursor             int64  `url:"cursor,omitempty"`
+	FilterToOwnedLists *bool  `url:"filter_to_owned_lists,omitempty"`
+}
+
+// Memberships returns a cursored collection of the lists the specified user
+// has been added to.
+// https://dev.twitter.com/rest/reference/get/lists/memberships
+func (s *ListService) Memberships(params *ListMembershipsParams) (*Lists, *http.Response, error) {
+	lists := new(Lists)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("memberships.json").QueryStruct(params).Receive(lists, apiError)
+	return lists, resp, relevantError(err, *apiError)
+}
+
+// ListOwnershipsParams are the parameters for ListService.Ownerships and
+// Subscriptions
+type ListOwnershipsParams struct {
+	UserID     int64  `url:"user_id,omitempty"`
+	ScreenName string `url:"screen_name,omitempty"`
+	Count      int    `url:"count,omitempty"`
+	Cursor     int64  `url:"cursor,omitempty"`
+}
+
+// Ownerships returns a cursored collection of the lists the specified user
+// owns.
+// https://dev.twitter.com/rest/reference/get/lists/ownerships
+func (s *ListService) Ownerships(params *ListOwnershipsParams) (*Lists, *http.Response, error) {
+	lists := new(Lists)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("ownerships.json").QueryStruct(params).Receive(lists, apiError)
+	return lists, resp, relevantError(err, *apiError)
+}
+
+// Subscriptions returns a cursored collection of the lists the specified
+// user subscribes to, not including their own lists.
+// https://dev.twitter.com/rest/reference/get/lists/subscriptions
+func (s *ListService) Subscriptions(params *ListOwnershipsParams) (*Lists, *http.Response, error) {
+	lists := new(Lists)
+	apiError := new(APIError)
+	resp, err := s.sling.New().Get("subscriptions.json").QueryStruct(params).Receive(lists, apiError)
+	return lists, resp, relevantError(err, *apiError)
+}
@@ -0,0 +1,328 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListService_List(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/list.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"screen_name": "twitterapi", "reverse": "true"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"id": 574, "slug": "meetup-20100301", "name": "meetup-20100301", "mode": "public", "member_count": 116, "user": {"id": 6253282}}]`)
+	})
+	expected := []List{List{ID: 574, Slug: "meetup-20100301", Name: "meetup-20100301", Mode: ListModePublic, MemberCount: 116, User: &User{ID: 6253282}}}
+
+	client := NewClient(httpClient)
+	lists, _, err := client.Lists.List(&ListListParams{ScreenName: "twitterapi", Reverse: Bool(true)})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lists)
+}
+
+func TestListService_Show(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/show.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"slug": "team", "owner_screen_name": "twitter"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": 8044403, "id_str": "8044403", "slug": "team", "full_name": "@twitter/team"}`)
+	})
+
+	client := NewClient(httpClient)
+	list, _, err := client.Lists.Show(&ListShowParams{Slug: "team", OwnerScreenName: "twitter"})
+	assert.Nil(t, err)
+	assert.Equal(t, &List{ID: 8044403, IDStr: "8044403", Slug: "team", FullName: "@twitter/team"}, list)
+}
+
+func TestListService_Statuses(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/statuses.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"list_id": "8044403", "since_id": "589147592367431680", "count": "2", "include_rts": "false", "tweet_mode": "extended"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"id": 589488862814076930, "full_text": "Hello"}, {"id": 589488862814076929}]`)
+	})
+	expected := []Tweet{Tweet{ID: 589488862814076930, FullText: "Hello"}, Tweet{ID: 589488862814076929}}
+
+	client := NewClient(httpClient)
+	params := &ListStatusesParams{
+		ListID:          8044403,
+		SinceID:         589147592367431680,
+		Count:           2,
+		IncludeRetweets: Bool(false),
+		TweetMode:       TweetModeExtended,
+	}
+	tweets, _, err := client.Lists.Statuses(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, tweets)
+}
+
+func TestListService_Create(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/create.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostForm(t, map[string]string{"name": "Goonies", "mode": "private", "description": "For life"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": 58300198, "name": "Goonies", "mode": "private", "description": "For life"}`)
+	})
+
+	client := NewClient(httpClient)
+	list, _, err := client.Lists.Create("Goonies", &ListCreateParams{Mode: ListModePrivate, Description: "For life"})
+	assert.Nil(t, err)
+	assert.Equal(t, &List{ID: 58300198, Name: "Goonies", Mode: ListModePrivate, Description: "For life"}, list)
+}
+
+func TestListService_CreateNilParams(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/create.json", func(w http.ResponseWriter, r *http.Request) {
+		assertPostForm(t, map[string]string{"name": "Goonies"}, r)
+	})
+	client := NewClient(httpClient)
+	client.Lists.Create("Goonies", nil)
+}
+
+func TestListService_Update(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/update.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostForm(t, map[string]string{"list_id": "58300198", "mode": "public"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": 58300198, "mode": "public"}`)
+	})
+
+	client := NewClient(httpClient)
+	list, _, err := client.Lists.Update(&ListUpdateParams{ListID: 58300198, Mode: ListModePublic})
+	assert.Nil(t, err)
+	assert.Equal(t, &List{ID: 58300198, Mode: ListModePublic}, list)
+}
+
+func TestListService_Destroy(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostForm(t, map[string]string{"slug": "goonies", "owner_id": "6253282"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": 58300198, "slug": "goonies"}`)
+	})
+
+	client := NewClient(httpClient)
+	list, _, err := client.Lists.Destroy(&ListShowParams{Slug: "goonies", OwnerID: 6253282})
+	assert.Nil(t, err)
+	assert.Equal(t, &List{ID: 58300198, Slug: "goonies"}, list)
+}
+
+func TestListService_Members(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/members.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"list_id": "8044403", "count": "5", "cursor": "1516933260114270762", "skip_status": "true"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"users": [{"id": 123}], "next_cursor": 1516837838944119498, "next_cursor_str": "1516837838944119498", "previous_cursor": 0, "previous_cursor_str": "0"}`)
+	})
+	expected := &ListMembers{
+		Users:             []User{User{ID: 123}},
+		NextCursor:        1516837838944119498,
+		NextCursorStr:     "1516837838944119498",
+		PreviousCursorStr: "0",
+	}
+
+	client := NewClient(httpClient)
+	params := &ListMembersParams{ListID: 8044403, Count: 5, Cursor: 1516933260114270762, SkipStatus: Bool(true)}
+	members, _, err := client.Lists.Members(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, members)
+}
+
+func TestListService_MembersShow(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/members/show.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"slug": "team", "owner_screen_name": "twitter", "screen_name": "froginthevalley"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(404)
+		fmt.Fprintf(w, `{"errors": [{"code": 109, "message": "The specified user is not a member of this list."}]}`)
+	})
+	expected := APIError{Errors: []ErrorDetail{ErrorDetail{Code: 109, Message: "The specified user is not a member of this list."}}}
+
+	client := NewClient(httpClient)
+	params := &ListMembersShowParams{Slug: "team", OwnerScreenName: "twitter", ScreenName: "froginthevalley"}
+	_, _, err := client.Lists.MembersShow(params)
+	assert.Equal(t, expected, err)
+}
+
+func TestListService_MembersCreateDestroy(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	for _, path := range []string{"create", "destroy"} {
+		mux.HandleFunc("/1.1/lists/members/"+path+".json", func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, "POST", r)
+			assertPostForm(t, map[string]string{"list_id": "8044403", "user_id": "623265148"}, r)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id": 8044403, "member_count": 12}`)
+		})
+	}
+	expected := &List{ID: 8044403, MemberCount: 12}
+
+	client := NewClient(httpClient)
+	params := &ListMembersCreateParams{ListID: 8044403, UserID: 623265148}
+	list, _, err := client.Lists.MembersCreate(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+	list, _, err = client.Lists.MembersDestroy(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+}
+
+func TestListService_MembersCreateAllDestroyAll(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	for _, path := range []string{"create_all", "destroy_all"} {
+		mux.HandleFunc("/1.1/lists/members/"+path+".json", func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, "POST", r)
+			assertPostForm(t, map[string]string{"list_id": "8044403", "screen_name": "dghubble,golang"}, r)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id": 8044403, "member_count": 13}`)
+		})
+	}
+	expected := &List{ID: 8044403, MemberCount: 13}
+
+	client := NewClient(httpClient)
+	params := &ListMembersCreateAllParams{ListID: 8044403, ScreenName: []string{"dghubble", "golang"}}
+	list, _, err := client.Lists.MembersCreateAll(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+	list, _, err = client.Lists.MembersDestroyAll(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+}
+
+func TestListService_Subscribers(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/subscribers.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"list_id": "8044403", "include_entities": "false"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"users": [{"id": 123}, {"id": 456}], "next_cursor": 0, "next_cursor_str": "0"}`)
+	})
+	expected := &ListMembers{Users: []User{User{ID: 123}, User{ID: 456}}, NextCursorStr: "0"}
+
+	client := NewClient(httpClient)
+	subscribers, _, err := client.Lists.Subscribers(&ListMembersParams{ListID: 8044403, IncludeEntities: Bool(false)})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, subscribers)
+}
+
+func TestListService_SubscribersShow(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/subscribers/show.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"list_id": "8044403", "user_id": "623265148"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": 623265148, "screen_name": "dghubble"}`)
+	})
+
+	client := NewClient(httpClient)
+	user, _, err := client.Lists.SubscribersShow(&ListMembersShowParams{ListID: 8044403, UserID: 623265148})
+	assert.Nil(t, err)
+	assert.Equal(t, &User{ID: 623265148, ScreenName: "dghubble"}, user)
+}
+
+func TestListService_SubscribersCreateDestroy(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	for _, path := range []string{"create", "destroy"} {
+		mux.HandleFunc("/1.1/lists/subscribers/"+path+".json", func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, "POST", r)
+			assertPostForm(t, map[string]string{"slug": "team", "owner_screen_name": "twitter"}, r)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id": 8044403, "following": true}`)
+		})
+	}
+	expected := &List{ID: 8044403, Following: true}
+
+	client := NewClient(httpClient)
+	params := &ListShowParams{Slug: "team", OwnerScreenName: "twitter"}
+	list, _, err := client.Lists.SubscribersCreate(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+	list, _, err = client.Lists.SubscribersDestroy(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, list)
+}
+
+func TestListService_Memberships(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/lists/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"screen_name": "dghubble", "count": "2", "filter_to_owned_lists": "true"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"lists": [{"id": 1}, {"id": 2}], "next_cursor": 1516837838944119498, "next_cursor_str": "1516837838944119498"}`)
+	})
+	expected := &Lists{
+		Lists:         []List{List{ID: 1}, List{ID: 2}},
+		NextCursor:    1516837838944119498,
+		NextCursorStr: "1516837838944119498",
+	}
+
+	client := NewClient(httpClient)
+	params := &ListMembershipsParams{ScreenName: "dghubble", Count: 2, FilterToOwnedLists: Bool(true)}
+	lists, _, err := client.Lists.Memberships(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lists)
+}
+
+func TestListService_OwnershipsSubscriptions(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	for _, path := range []string{"ownerships", "subscriptions"} {
+		mux.HandleFunc("/1.1/lists/"+path+".json", func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, "GET", r)
+			assertQuery(t, map[string]string{"user_id": "623265148", "cursor": "-1"}, r)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"lists": [{"id": 3}], "next_cursor": 0, "next_cursor_str": "0"}`)
+		})
+	}
+	expected := &Lists{Lists: []List{List{ID: 3}}, NextCursorStr: "0"}
+
+	client := NewClient(httpClient)
+	params := &ListOwnershipsParams{UserID: 623265148, Cursor: -1}
+	lists, _, err := client.Lists.Ownerships(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lists)
+	lists, _, err = client.Lists.Subscriptions(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lists)
+}
@@ -0,0 +1,196 @@
+package twitter
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RenderFormat is an output format for RenderTweet and RenderText.
+type RenderFormat int
+
+// Render formats.
+const (
+	// RenderPlain renders plain text with expanded links.
+	RenderPlain RenderFormat = iota
+	// RenderHTML renders escaped HTML with links for URLs, mentions, and
+	// hashtags.
+	RenderHTML
+	// RenderMarkdown renders escaped Markdown with links for URLs, mentions,
+	// and hashtags.
+	RenderMarkdown
+)
+
+// renderSpan is an entity to render in place of a range of text.
+type renderSpan struct {
+	indices Indices
+	// href is the link target, or empty to remove the text
+	href string
+	// display is the link text, or empty to use the original text
+	display string
+}
+
+// RenderTweet renders the untruncated text of a Tweet in the given format,
+// using its entities. See RenderText.
+func RenderTweet(tweet *Tweet, format RenderFormat) string {
+	return RenderText(tweet.FullTextOrText(), tweet.FullEntities(), format)
+}
+
+// RenderText renders Tweet text in the given format, using the entities
+// parsed from it. URLs are expanded, mentions and hashtags are linked to
+// twitter.com, and media URLs are removed. The text is HTML-escaped, as
+// returned by the API, and entity Indices count Unicode code points of the
+// unescaped text, so characters outside the Basic Multilingual Plane (e.g.
+// emoji), which are UTF-16 surrogate pairs, count as one. Entities which
+// overlap an earlier entity or lie outside the text are ignored.
+func RenderText(text string, entities *Entities, format RenderFormat) string {
+	runes := []rune(html.UnescapeString(text))
+	var spans []renderSpan
+	if entities != nil {
+		for _, u := range entities.Urls {
+			href := u.ExpandedURL
+			if href == "" {
+				href = u.URL
+			}
+			display := u.DisplayURL
+			if display == "" {
+				display = href
+			}
+			spans = append(spans, renderSpan{indices: u.Indices, href: href, display: display})
+		}
+		for _, media := range entities.Media {
+			spans = append(spans, renderSpan{indices: media.Indices})
+		}
+		for _, mention := range entities.UserMentions {
+			spans = append(spans, renderSpan{
+				indices: mention.Indices,
+				href:    "https://twitter.com/" + url.QueryEscape(mention.ScreenName),
+			})
+		}
+		for _, hashtag := range entities.Hashtags {
+			spans = append(spans, renderSpan{
+				indices: hashtag.Indices,
+				href:    "https://twitter.com/hashtag/" + url.QueryEscape(hashtag.Text),
+			})
+		}
+	}
+	sort.Sort(spansByStart(spans))
+
+	var buf bytes.Buffer
+	pos := 0
+	// trimLeft drops the gap after media stripped from the start of the text
+	trimLeft := false
+	for _, span := range spans {
+		start, end := span.indices.Start(), span.indices.End()
+		if start < pos || start > end || end > len(runes) {
+			continue
+		}
+		segment := string(runes[pos:start])
+		if trimLeft {
+			segment = strings.TrimLeftFunc(segment, unicode.IsSpace)
+		}
+		if span.href == "" {
+			// drop the media URL and the space separating it from the text
+			// before it
+			segment = strings.TrimRightFunc(segment, unicode.IsSpace)
+			buf.WriteString(renderEscape(segment, format, atLineStart(&buf)))
+			trimLeft = buf.Len() == 0
+		} else {
+			buf.WriteString(renderEscape(segment, format, atLineStart(&buf)))
+			buf.WriteString(renderLink(span, string(runes[start:end]), format, atLineStart(&buf)))
+			trimLeft = false
+		}
+		pos = end
+	}
+	segment := string(runes[pos:])
+	if trimLeft {
+		segment = strings.TrimLeftFunc(segment, unicode.IsSpace)
+	}
+	buf.WriteString(renderEscape(segment, format, atLineStart(&buf)))
+	return buf.String()
+}
+
+// atLineStart returns true if text written next to the buffer starts a line.
+func atLineStart(buf *bytes.Buffer) bool {
+	return buf.Len() == 0 || bytes.HasSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// renderLink renders an entity as a link in the given format. Links which
+// are not http or https URLs are rendered as text, which starts a line if
+// lineStart is true.
+func renderLink(span renderSpan, original string, format RenderFormat, lineStart bool) string {
+	display := span.display
+	if display == "" {
+		display = original
+	}
+	if u, err := url.Parse(span.href); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return renderEscape(original, format, lineStart)
+	}
+	switch format {
+	case RenderHTML:
+		return `<a href="` + html.EscapeString(span.href) + `">` + html.EscapeString(display) + "</a>"
+	case RenderMarkdown:
+		return "[" + markdownEscape(display, false) + "](" + markdownURLReplacer.Replace(span.href) + ")"
+	}
+	if span.display != "" {
+		// expand URLs in plain text
+		return span.href
+	}
+	return original
+}
+
+// renderEscape escapes text for the given format. The text starts a line if
+// lineStart is true.
+func renderEscape(text string, format RenderFormat, lineStart bool) string {
+	switch format {
+	case RenderHTML:
+		return html.EscapeString(text)
+	case RenderMarkdown:
+		return markdownEscape(text, lineStart)
+	}
+	return text
+}
+
+// markdownSpecial are the characters escaped in Markdown text.
+const markdownSpecial = "\\`*_[]<>~|"
+
+// markdownLineStart are the characters escaped at the start of a line, after
+// any indentation, where they begin a heading or a list.
+const markdownLineStart = "#-+="
+
+// markdownEscape backslash escapes Markdown formatting characters, and the
+// heading and list markers at the start of each line. The text starts a line
+// if lineStart is true.
+func markdownEscape(text string, lineStart bool) string {
+	var buf bytes.Buffer
+	// number is true after the digits of a possible ordered list marker
+	start, number := lineStart, false
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(markdownSpecial, r):
+			buf.WriteByte('\\')
+		case start && strings.ContainsRune(markdownLineStart, r):
+			buf.WriteByte('\\')
+		case number && (r == '.' || r == ')'):
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+		number = (start || number) && r >= '0' && r <= '9'
+		start = r == '\n' || (start && (r == ' ' || r == '\t'))
+	}
+	return buf.String()
+}
+
+// markdownURLReplacer escapes characters which end a Markdown link
+// destination.
+var markdownURLReplacer = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20", "<", "%3C", ">", "%3E")
+
+// spansByStart sorts renderSpans by start index.
+type spansByStart []renderSpan
+
+func (s spansByStart) Len() int           { return len(s) }
+func (s spansByStart) Less(i, j int) bool { return s[i].indices.Start() < s[j].indices.Start() }
+func (s spansByStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
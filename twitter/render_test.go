@@ -0,0 +1,115 @@
+package twitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// renderTweet has an emoji, which is a UTF-16 surrogate pair, and an escaped
+// ampersand before its entities.
+var renderTweet = &Tweet{
+	Text: "@golang Tom &amp; Jerry 😀 #go https://t.co/abc https://t.co/img",
+	Entities: &Entities{
+		UserMentions: []MentionEntity{{Indices: Indices{0, 7}, ScreenName: "golang"}},
+		Hashtags:     []HashtagEntity{{Indices: Indices{22, 25}, Text: "go"}},
+		Urls: []URLEntity{{
+			Indices:     Indices{26, 42},
+			URL:         "https://t.co/abc",
+			DisplayURL:  "golang.org/doc",
+			ExpandedURL: "https://golang.org/doc?a=1&b=2",
+		}},
+		Media: []MediaEntity{{URLEntity: URLEntity{Indices: Indices{43, 59}, URL: "https://t.co/img"}}},
+	},
+}
+
+func TestRenderTweet(t *testing.T) {
+	cases := []struct {
+		format   RenderFormat
+		expected string
+	}{
+		{RenderPlain, "@golang Tom & Jerry 😀 #go https://golang.org/doc?a=1&b=2"},
+		{RenderHTML, `<a href="https://twitter.com/golang">@golang</a> Tom &amp; Jerry 😀 ` +
+			`<a href="https://twitter.com/hashtag/go">#go</a> ` +
+			`<a href="https://golang.org/doc?a=1&amp;b=2">golang.org/doc</a>`},
+		{RenderMarkdown, "[@golang](https://twitter.com/golang) Tom & Jerry 😀 " +
+			"[#go](https://twitter.com/hashtag/go) [golang.org/doc](https://golang.org/doc?a=1&b=2)"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, RenderTweet(renderTweet, c.format))
+	}
+}
+
+func TestRenderText_Media(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected string
+	}{
+		{"hello\n\nhttps://t.co/img", "hello"},
+		{"look https://t.co/img at this", "look at this"},
+		{"https://t.co/img hello", "hello"},
+		// whitespace which was not next to the media URL is kept
+		{"hello https://t.co/img\n", "hello\n"},
+		{" hello  https://t.co/img", " hello"},
+	}
+	for _, c := range cases {
+		start := len([]rune(c.text[:strings.Index(c.text, "https")]))
+		entities := &Entities{Media: []MediaEntity{{URLEntity: URLEntity{Indices: Indices{start, start + 16}, URL: "https://t.co/img"}}}}
+		assert.Equal(t, c.expected, RenderText(c.text, entities, RenderPlain), c.text)
+	}
+}
+
+func TestRenderText_Escaping(t *testing.T) {
+	text := "&lt;script&gt;alert(1)&lt;/script&gt; *bold* [link](x) https://t.co/js"
+	entities := &Entities{Urls: []URLEntity{{Indices: Indices{43, 58}, URL: "https://t.co/js", ExpandedURL: "javascript:alert(1)"}}}
+	assert.Equal(t, "<script>alert(1)</script> *bold* [link](x) https://t.co/js", RenderText(text, entities, RenderPlain))
+	assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; *bold* [link](x) https://t.co/js", RenderText(text, entities, RenderHTML))
+	assert.Equal(t, `\<script\>alert(1)\</script\> \*bold\* \[link\](x) https://t.co/js`, RenderText(text, entities, RenderMarkdown))
+}
+
+func TestRenderText_MarkdownLineStart(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected string
+	}{
+		{"# not a heading", `\# not a heading`},
+		{"- not a list\n+ or this\n  * or this", "\\- not a list\n\\+ or this\n  \\* or this"},
+		{"1. not a list\n2) or this", "1\\. not a list\n2\\) or this"},
+		{"heading\n===", "heading\n\\==="},
+		// markers within a line are text
+		{"C# - version 1. #go", "C# - version 1. #go"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, RenderText(c.text, nil, RenderMarkdown), c.text)
+	}
+	// a line may start after an entity
+	text := "@golang\n- 1.9 https://t.co/abc"
+	entities := &Entities{UserMentions: []MentionEntity{{Indices: Indices{0, 7}, ScreenName: "golang"}}}
+	assert.Equal(t, "[@golang](https://twitter.com/golang)\n\\- 1.9 https://t.co/abc", RenderText(text, entities, RenderMarkdown))
+}
+
+func TestRenderText_InvalidEntities(t *testing.T) {
+	entities := &Entities{
+		Hashtags: []HashtagEntity{
+			{Indices: Indices{0, 6}, Text: "gopher"},
+			// overlaps the previous entity
+			{Indices: Indices{3, 6}, Text: "her"},
+			// outside the text
+			{Indices: Indices{8, 20}, Text: "golang"},
+		},
+	}
+	assert.Equal(t, `<a href="https://twitter.com/hashtag/gopher">gopher</a> #golang`, RenderText("gopher #golang", entities, RenderHTML))
+	assert.Equal(t, "no entities", RenderText("no entities", nil, RenderMarkdown))
+}
+
+func TestRenderTweet_Extended(t *testing.T) {
+	tweet := &Tweet{
+		Text: "a truncated Tweet… https://t.co/abc",
+		ExtendedTweet: &ExtendedTweet{
+			FullText: "a long Tweet about #golang",
+			Entities: &Entities{Hashtags: []HashtagEntity{{Indices: Indices{19, 26}, Text: "golang"}}},
+		},
+	}
+	assert.Equal(t, "a long Tweet about [#golang](https://twitter.com/hashtag/golang)", RenderTweet(tweet, RenderMarkdown))
+}
@@ -2,7 +2,6 @@ package twitter
 
 import (
 	"net/http"
-	"net/url"
 
 	"github.com/dghubble/sling"
 )
@@ -51,12 +50,12 @@ type SearchParams struct {
 	MaxID           int64  `url:"max_id,omitempty"`
 	IncludeEntities bool   `url:"include_entities,omitempty"`
 	NextResults     bool   `url:"q,omitempty"`
+	TweetMode       string `url:"tweet_mode,omitempty"`
 }
 
 // Search returns a cursored collection of user ids following the specified user.
 // https://dev.twitter.com/rest/reference/get/friends/ids
 func (s *SearchService) Search(params *SearchParams) (*Search, *http.Response, error) {
-	params.Query = url.QueryEscape(params.Query)
 	ids := new(Search)
 	apiError := new(APIError)
 	resp, err := s.sling.New().Get("tweets.json").QueryStruct(params).Receive(ids, apiError)
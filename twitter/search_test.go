@@ -0,0 +1,39 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"q": "#golang OR gophercon", "count": "1", "since_id": "20"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"statuses": [{"id": 781760642139250689}], "search_metadata": {"max_id": 781760642139250689, "query": "%%23golang+OR+gophercon", "count": 1, "since_id": 20}}`)
+	})
+
+	client := NewClient(httpClient)
+	search, _, err := client.Search.Search(&SearchParams{
+		Query:   "#golang OR gophercon",
+		Count:   1,
+		SinceID: 20,
+	})
+	expected := &Search{
+		Statuses: []*Tweet{&Tweet{ID: 781760642139250689}},
+		SearchMetaData: SearchMetaData{
+			MaxID:   781760642139250689,
+			Query:   "%23golang+OR+gophercon",
+			Count:   1,
+			SinceID: 20,
+		},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, search)
+}
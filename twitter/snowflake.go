@@ -0,0 +1,93 @@
+package twitter
+
+import (
+	"errors"
+	"time"
+)
+
+// Snowflake ID layout: 41 bits of milliseconds since the snowflake epoch,
+// 5 bits of datacenter ID, 5 bits of worker ID, and a 12 bit sequence.
+// https://github.com/twitter/snowflake/tree/snowflake-2010
+const (
+	snowflakeEpoch          = 1288834974657
+	snowflakeTimestampShift = 22
+	snowflakeDatacenterBits = 5
+	snowflakeWorkerBits     = 5
+	snowflakeSequenceBits   = 12
+)
+
+// ErrEmptySnowflakeRange is returned by SnowflakeRange when no Twitter ID can
+// be in the range.
+var ErrEmptySnowflakeRange = errors.New("twitter: snowflake range is empty")
+
+// Snowflake is a decoded Twitter ID. Tweet and Direct Message IDs are
+// snowflakes, except those created before November 2010.
+type Snowflake struct {
+	Time       time.Time
+	Datacenter int64
+	Worker     int64
+	Sequence   int64
+}
+
+// ParseSnowflake decodes the creation time, datacenter, worker, and sequence
+// from a Twitter ID.
+func ParseSnowflake(id int64) Snowflake {
+	return Snowflake{
+		Time:       SnowflakeTime(id),
+		Datacenter: id >> (snowflakeWorkerBits + snowflakeSequenceBits) & (1<<snowflakeDatacenterBits - 1),
+		Worker:     id >> snowflakeSequenceBits & (1<<snowflakeWorkerBits - 1),
+		Sequence:   id & (1<<snowflakeSequenceBits - 1),
+	}
+}
+
+// SnowflakeTime returns the time a Twitter ID was created, to the
+// millisecond.
+func SnowflakeTime(id int64) time.Time {
+	ms := id>>snowflakeTimestampShift + snowflakeEpoch
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond))
+}
+
+// MinSnowflakeID returns the smallest Twitter ID which could be created
+// within the millisecond of the given time. Returns 0 for times before the
+// snowflake epoch.
+func MinSnowflakeID(t time.Time) int64 {
+	ms := t.UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms < 0 {
+		return 0
+	}
+	return ms << snowflakeTimestampShift
+}
+
+// MaxSnowflakeID returns the largest Twitter ID which could be created
+// within the millisecond of the given time. Returns 0 for times before the
+// snowflake epoch.
+func MaxSnowflakeID(t time.Time) int64 {
+	ms := t.UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms < 0 {
+		return 0
+	}
+	return ms<<snowflakeTimestampShift | (1<<snowflakeTimestampShift - 1)
+}
+
+// SnowflakeRange returns the SinceID and MaxID params which select Tweets
+// created from since (inclusive) until (exclusive), to the millisecond. A
+// zero time leaves that end of the range open and returns a 0 param. Times
+// before the snowflake epoch (November 2010) are not supported. Returns
+// ErrEmptySnowflakeRange if until is not after since or not after the
+// snowflake epoch, since a 0 MaxID would select every Tweet instead.
+func SnowflakeRange(since, until time.Time) (sinceID, maxID int64, err error) {
+	if !since.IsZero() {
+		// since_id is exclusive
+		if sinceID = MinSnowflakeID(since) - 1; sinceID < 0 {
+			sinceID = 0
+		}
+	}
+	if !until.IsZero() {
+		// max_id is inclusive
+		maxID = MinSnowflakeID(until) - 1
+		if maxID <= 0 || maxID <= sinceID {
+			return 0, 0, ErrEmptySnowflakeRange
+		}
+	}
+	return sinceID, maxID, nil
+}
@@ -0,0 +1,85 @@
+package twitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSnowflake(t *testing.T) {
+	snowflake := ParseSnowflake(590657983463968768)
+	assert.Equal(t, Snowflake{
+		Time:       time.Unix(1429658811, 809000000),
+		Datacenter: 15,
+		Worker:     5,
+		Sequence:   0,
+	}, snowflake)
+	assert.Equal(t, time.Date(2015, 4, 21, 23, 26, 51, 809000000, time.UTC), snowflake.Time.UTC())
+	snowflake = ParseSnowflake(MinSnowflakeID(snowflake.Time) | 3<<17 | 7<<12 | 42)
+	assert.Equal(t, int64(3), snowflake.Datacenter)
+	assert.Equal(t, int64(7), snowflake.Worker)
+	assert.Equal(t, int64(42), snowflake.Sequence)
+}
+
+func TestMinMaxSnowflakeID(t *testing.T) {
+	created := time.Unix(1429658811, 809000000)
+	min, max := MinSnowflakeID(created), MaxSnowflakeID(created)
+	assert.True(t, min <= 590657983463968768 && 590657983463968768 <= max)
+	assert.Equal(t, int64(1<<22-1), max-min)
+	assert.Equal(t, created, SnowflakeTime(min))
+	assert.Equal(t, created, SnowflakeTime(max))
+	// sub-millisecond times are truncated
+	assert.Equal(t, min, MinSnowflakeID(created.Add(999*time.Microsecond)))
+	assert.Equal(t, max+1, MinSnowflakeID(created.Add(time.Millisecond)))
+
+	before := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(0), MinSnowflakeID(before))
+	assert.Equal(t, int64(0), MaxSnowflakeID(before))
+}
+
+func TestSnowflakeRange(t *testing.T) {
+	since := time.Date(2015, 4, 21, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	sinceID, maxID, err := SnowflakeRange(since, until)
+	assert.Nil(t, err)
+	// since_id is exclusive and max_id inclusive
+	assert.Equal(t, MinSnowflakeID(since)-1, sinceID)
+	assert.Equal(t, MaxSnowflakeID(until.Add(-time.Millisecond)), maxID)
+	assert.True(t, sinceID < 590657983463968768 && 590657983463968768 <= maxID)
+
+	sinceID, maxID, err = SnowflakeRange(since, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, MinSnowflakeID(since)-1, sinceID)
+	assert.Equal(t, int64(0), maxID)
+	sinceID, maxID, err = SnowflakeRange(time.Time{}, until)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), sinceID)
+	assert.Equal(t, MinSnowflakeID(until)-1, maxID)
+}
+
+func TestSnowflakeRange_Empty(t *testing.T) {
+	epoch := time.Unix(0, snowflakeEpoch*int64(time.Millisecond))
+	before := time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2015, 4, 21, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		since, until time.Time
+	}{
+		// a 0 max_id would select every Tweet
+		{time.Time{}, before},
+		{time.Time{}, epoch},
+		{before, epoch},
+		{since, since},
+		{since, since.Add(-time.Hour)},
+	}
+	for _, c := range cases {
+		sinceID, maxID, err := SnowflakeRange(c.since, c.until)
+		assert.Equal(t, ErrEmptySnowflakeRange, err, "%v %v", c.since, c.until)
+		assert.Equal(t, int64(0), sinceID)
+		assert.Equal(t, int64(0), maxID)
+	}
+	// ranges ending just after the epoch are not empty
+	_, maxID, err := SnowflakeRange(before, epoch.Add(time.Millisecond))
+	assert.Nil(t, err)
+	assert.True(t, maxID > 0)
+}
@@ -1,8 +1,11 @@
 package twitter
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/dghubble/sling"
 )
@@ -44,6 +47,121 @@ type Tweet struct {
 	QuotedStatusID       int64                  `json:"quoted_status_id"`
 	QuotedStatusIDStr    string                 `json:"quoted_status_id_str"`
 	QuotedStatus         *Tweet                 `json:"quoted_status"`
+	FullText             string                 `json:"full_text"`
+	DisplayTextRange     Indices                `json:"display_text_range"`
+	ExtendedTweet        *ExtendedTweet         `json:"extended_tweet"`
+	// Backfilled is true for Tweets a Stream found by searching rather than
+	// receiving them, see StreamService.FilterBackfill.
+	Backfilled bool `json:"-"`
+	// Extra are the JSON fields of the Tweet which are not decoded into
+	// other fields. They are encoded with the Tweet, so it round-trips.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Tweet modes for the TweetMode param. Extended mode returns the untruncated
+// FullText of Tweets instead of Text.
+// https://dev.twitter.com/overview/api/upcoming-changes-to-tweets
+const (
+	TweetModeCompat   = "compat"
+	TweetModeExtended = "extended"
+)
+
+// ExtendedTweet is the untruncated text and entities of a Tweet longer than
+// 140 characters, sent by the Streaming API in place of FullText.
+type ExtendedTweet struct {
+	FullText         string          `json:"full_text"`
+	DisplayTextRange Indices         `json:"display_text_range"`
+	Entities         *Entities       `json:"entities"`
+	ExtendedEntities *ExtendedEntity `json:"extended_entities"`
+}
+
+// FullTextOrText returns the untruncated text of the Tweet from the streaming
+// extended_tweet or the extended mode full_text, falling back to Text.
+func (t Tweet) FullTextOrText() string {
+	if t.ExtendedTweet != nil && t.ExtendedTweet.FullText != "" {
+		return t.ExtendedTweet.FullText
+	}
+	if t.FullText != "" {
+		return t.FullText
+	}
+	return t.Text
+}
+
+// FullEntities returns the Entities whose indices match FullTextOrText. The
+// Media are those of the ExtendedEntities, if any, which include every photo
+// rather than only the first.
+func (t Tweet) FullEntities() *Entities {
+	entities, extended := t.Entities, t.ExtendedEntities
+	if t.ExtendedTweet != nil && t.ExtendedTweet.FullText != "" {
+		entities, extended = t.ExtendedTweet.Entities, t.ExtendedTweet.ExtendedEntities
+	}
+	if extended == nil || len(extended.Media) == 0 {
+		return entities
+	}
+	merged := new(Entities)
+	if entities != nil {
+		*merged = *entities
+	}
+	merged.Media = extended.Media
+	return merged
+}
+
+// CreatedAtTime returns the time the Tweet was created.
+func (t Tweet) CreatedAtTime() (time.Time, error) {
+	return time.Parse(time.RubyDate, t.CreatedAt)
+}
+
+// SortTweetsByCreatedAt sorts Tweets oldest first by CreatedAt, then by ID.
+// Tweets whose CreatedAt cannot be parsed sort first.
+func SortTweetsByCreatedAt(tweets []Tweet) {
+	sorter := newCreatedAtSorter(len(tweets), func(i int) *Tweet { return &tweets[i] })
+	sorter.swap = func(i, j int) { tweets[i], tweets[j] = tweets[j], tweets[i] }
+	sort.Sort(sorter)
+}
+
+// SortTweetPointersByCreatedAt sorts Tweets oldest first by CreatedAt, then
+// by ID, like SortTweetsByCreatedAt.
+func SortTweetPointersByCreatedAt(tweets []*Tweet) {
+	sorter := newCreatedAtSorter(len(tweets), func(i int) *Tweet { return tweets[i] })
+	sorter.swap = func(i, j int) { tweets[i], tweets[j] = tweets[j], tweets[i] }
+	sort.Sort(sorter)
+}
+
+// createdAtSorter sorts a slice of Tweets by created_at time and ID, parsing
+// each time once.
+type createdAtSorter struct {
+	times []time.Time
+	ids   []int64
+	swap  func(i, j int)
+}
+
+func newCreatedAtSorter(n int, tweet func(i int) *Tweet) *createdAtSorter {
+	s := &createdAtSorter{
+		times: make([]time.Time, n),
+		ids:   make([]int64, n),
+	}
+	for i := 0; i < n; i++ {
+		t := tweet(i)
+		// unparseable times are zero and sort first
+		s.times[i], _ = t.CreatedAtTime()
+		s.ids[i] = t.ID
+	}
+	return s
+}
+
+func (s *createdAtSorter) Len() int { return len(s.times) }
+
+func (s *createdAtSorter) Less(i, j int) bool {
+	if !s.times[i].Equal(s.times[j]) {
+		return s.times[i].Before(s.times[j])
+	}
+	return s.ids[i] < s.ids[j]
+}
+
+func (s *createdAtSorter) Swap(i, j int) {
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+	s.swap(i, j)
 }
 
 // Place represents a Twitter Place / Location
@@ -90,22 +208,25 @@ type TweetIdentifier struct {
 
 // StatusService provides methods for accessing Twitter status API endpoints.
 type StatusService struct {
-	sling *sling.Sling
+	sling  *sling.Sling
+	search *SearchService
 }
 
 // newStatusService returns a new StatusService.
-func newStatusService(sling *sling.Sling) *StatusService {
+func newStatusService(sling *sling.Sling, search *SearchService) *StatusService {
 	return &StatusService{
-		sling: sling.Path("statuses/"),
+		sling:  sling.Path("statuses/"),
+		search: search,
 	}
 }
 
 // StatusShowParams are the parameters for StatusService.Show
 type StatusShowParams struct {
-	ID               int64 `url:"id,omitempty"`
-	TrimUser         *bool `url:"trim_user,omitempty"`
-	IncludeMyRetweet *bool `url:"include_my_retweet,omitempty"`
-	IncludeEntities  *bool `url:"include_entities,omitempty"`
+	ID               int64  `url:"id,omitempty"`
+	TrimUser         *bool  `url:"trim_user,omitempty"`
+	IncludeMyRetweet *bool  `url:"include_my_retweet,omitempty"`
+	IncludeEntities  *bool  `url:"include_entities,omitempty"`
+	TweetMode        string `url:"tweet_mode,omitempty"`
 }
 
 // Show returns the requested Tweet.
@@ -127,6 +248,7 @@ type StatusLookupParams struct {
 	TrimUser        *bool   `url:"trim_user,omitempty"`
 	IncludeEntities *bool   `url:"include_entities,omitempty"`
 	Map             *bool   `url:"map,omitempty"`
+	TweetMode       string  `url:"tweet_mode,omitempty"`
 }
 
 // Lookup returns the requested Tweets as a slice. Combines ids from the
@@ -154,6 +276,7 @@ type StatusUpdateParams struct {
 	DisplayCoordinates *bool    `url:"display_coordinates,omitempty"`
 	TrimUser           *bool    `url:"trim_user,omitempty"`
 	MediaIds           []int64  `url:"media_ids,omitempty,comma"`
+	TweetMode          string   `url:"tweet_mode,omitempty"`
 }
 
 // Update updates the user's status, also known as Tweeting.
@@ -172,8 +295,9 @@ func (s *StatusService) Update(status string, params *StatusUpdateParams) (*Twee
 
 // StatusRetweetParams are the parameters for StatusService.Retweet
 type StatusRetweetParams struct {
-	ID       int64 `url:"id,omitempty"`
-	TrimUser *bool `url:"trim_user,omitempty"`
+	ID        int64  `url:"id,omitempty"`
+	TrimUser  *bool  `url:"trim_user,omitempty"`
+	TweetMode string `url:"tweet_mode,omitempty"`
 }
 
 // Retweet retweets the Tweet with the given id and returns the original Tweet
@@ -209,9 +333,10 @@ func (s *StatusService) UnRetweet(id int64, params *StatusRetweetParams) (*Tweet
 
 // StatusRetweetsParams are the parameters for StatusService.Retweets
 type StatusRetweetsParams struct {
-	ID       int64 `url:"id,omitempty"`
-	Count    int   `url:"count,omitempty"`
-	TrimUser *bool `url:"trim_user,omitempty"`
+	ID        int64  `url:"id,omitempty"`
+	Count     int    `url:"count,omitempty"`
+	TrimUser  *bool  `url:"trim_user,omitempty"`
+	TweetMode string `url:"tweet_mode,omitempty"`
 }
 
 // Retweets returns the most recent retweets of the Tweet with the given id.
@@ -230,12 +355,13 @@ func (s *StatusService) Retweets(id int64, params *StatusRetweetsParams) ([]Twee
 
 // StatusRetweetsOfMeParams are the parameters for StatusService.RetweetsOfMe
 type StatusRetweetsOfMeParams struct {
-	Count               int64 `url:"count,omitempty"`
-	SinceID             int64 `url:"since_id,omitempty"`
-	MaxID               int64 `url:"max_id,omitempty"`
-	TrimUser            bool  `url:"trim_user,omitempty"`
-	IncludeEntities     bool  `url:"include_entities,omitempty"`
-	IncludeUserEntities bool  `url:"include_user_entities,omitempty"`
+	Count               int64  `url:"count,omitempty"`
+	SinceID             int64  `url:"since_id,omitempty"`
+	MaxID               int64  `url:"max_id,omitempty"`
+	TrimUser            bool   `url:"trim_user,omitempty"`
+	IncludeEntities     bool   `url:"include_entities,omitempty"`
+	IncludeUserEntities bool   `url:"include_user_entities,omitempty"`
+	TweetMode           string `url:"tweet_mode,omitempty"`
 }
 
 // RetweetsOfMe returns the most recent tweets authored by the authenticating
@@ -279,8 +405,9 @@ func (s *StatusService) Retweeters(params *StatusRetweetersParams) (StatusRetwee
 
 // StatusDestroyParams are the parameters for StatusService.Destroy
 type StatusDestroyParams struct {
-	ID       int64 `url:"id,omitempty"`
-	TrimUser *bool `url:"trim_user,omitempty"`
+	ID        int64  `url:"id,omitempty"`
+	TrimUser  *bool  `url:"trim_user,omitempty"`
+	TweetMode string `url:"tweet_mode,omitempty"`
 }
 
 // Destroy deletes the Tweet with the given id and returns it if successful.
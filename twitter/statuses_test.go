@@ -1,10 +1,12 @@
 package twitter
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -315,3 +317,88 @@ func TestStatusService_OEmbed(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, oembed)
 }
+
+func TestTweet_CreatedAtTime(t *testing.T) {
+	tweet := Tweet{CreatedAt: "Tue Apr 21 23:48:58 +0000 2015"}
+	createdAt, err := tweet.CreatedAtTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2015, 4, 21, 23, 48, 58, 0, time.UTC), createdAt.UTC())
+	_, err = Tweet{CreatedAt: "2015-04-21"}.CreatedAtTime()
+	assert.Error(t, err)
+}
+
+func TestSortTweetsByCreatedAt(t *testing.T) {
+	tweets := []Tweet{
+		{ID: 4, CreatedAt: "Tue Apr 21 23:49:00 +0000 2015"},
+		{ID: 3, CreatedAt: "Tue Apr 21 23:48:58 +0000 2015"},
+		{ID: 5, CreatedAt: "invalid"},
+		{ID: 2, CreatedAt: "Tue Apr 21 23:48:58 +0000 2015"},
+		// same time in another zone
+		{ID: 1, CreatedAt: "Tue Apr 21 16:48:58 -0700 2015"},
+	}
+	SortTweetsByCreatedAt(tweets)
+	var ids []int64
+	for _, tweet := range tweets {
+		ids = append(ids, tweet.ID)
+	}
+	assert.Equal(t, []int64{5, 1, 2, 3, 4}, ids)
+
+	pointers := []*Tweet{
+		{ID: 2, CreatedAt: "Tue Apr 21 23:49:00 +0000 2015"},
+		{ID: 1, CreatedAt: "Tue Apr 21 23:48:58 +0000 2015"},
+	}
+	SortTweetPointersByCreatedAt(pointers)
+	assert.Equal(t, int64(1), pointers[0].ID)
+	assert.Equal(t, int64(2), pointers[1].ID)
+}
+
+func TestStatusService_ShowExtended(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"id": "589488862814076930", "tweet_mode": "extended"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"full_text": "@golang gophers everywhere", "display_text_range": [8, 26], "truncated": false}`)
+	})
+
+	client := NewClient(httpClient)
+	tweet, _, err := client.Statuses.Show(589488862814076930, &StatusShowParams{TweetMode: TweetModeExtended})
+	expected := &Tweet{FullText: "@golang gophers everywhere", DisplayTextRange: Indices{8, 26}}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, tweet)
+	assert.Equal(t, "@golang gophers everywhere", tweet.FullTextOrText())
+}
+
+func TestTweet_FullTextOrText(t *testing.T) {
+	var tweet Tweet
+	err := json.Unmarshal([]byte(`{
+		"text": "a truncated Tweet… https://t.co/abc",
+		"truncated": true,
+		"entities": {"urls": [{"url": "https://t.co/abc", "indices": [19, 35]}]},
+		"extended_tweet": {
+			"full_text": "a long Tweet #golang https://t.co/img",
+			"display_text_range": [0, 20],
+			"entities": {
+				"hashtags": [{"text": "golang", "indices": [13, 20]}],
+				"media": [{"id": 1, "indices": [21, 37]}]
+			},
+			"extended_entities": {"media": [{"id": 1, "indices": [21, 37]}, {"id": 2, "indices": [21, 37]}]}
+		}
+	}`), &tweet)
+	assert.NoError(t, err)
+	assert.Equal(t, "a long Tweet #golang https://t.co/img", tweet.FullTextOrText())
+	assert.Equal(t, Indices{0, 20}, tweet.ExtendedTweet.DisplayTextRange)
+	entities := tweet.FullEntities()
+	assert.Equal(t, []HashtagEntity{{Indices: Indices{13, 20}, Text: "golang"}}, entities.Hashtags)
+	assert.Len(t, entities.Media, 2)
+	// the extended_tweet entities are not modified
+	assert.Len(t, tweet.ExtendedTweet.Entities.Media, 1)
+
+	assert.Equal(t, "text", Tweet{Text: "text"}.FullTextOrText())
+	assert.Equal(t, "full", Tweet{Text: "text", FullText: "full"}.FullTextOrText())
+	assert.Nil(t, Tweet{}.FullEntities())
+	entities = Tweet{ExtendedEntities: &ExtendedEntity{Media: []MediaEntity{{ID: 3}}}}.FullEntities()
+	assert.Equal(t, &Entities{Media: []MediaEntity{{ID: 3}}}, entities)
+}
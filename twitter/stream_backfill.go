@@ -0,0 +1,202 @@
+package twitter
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// backfillCount is the number of Tweets requested per backfill search.
+const backfillCount = 100
+
+// maxSearchQueryLength is the longest URL encoded query Search accepts.
+// https://dev.twitter.com/rest/reference/get/search/tweets
+const maxSearchQueryLength = 500
+
+// streamBackfill searches for Tweets a Stream missed while reconnecting. It
+// records the highest Tweet ID received and searches for newer Tweets which
+// match the Track predicates of a filter stream.
+type streamBackfill struct {
+	search *SearchService
+	mu     sync.Mutex
+	// queries are the Track predicates, split to fit the search query limit
+	queries []string
+	// sinceID is the highest Tweet ID received
+	sinceID int64
+	// resume is true until the first backfill of a resumed stream
+	resume bool
+	// sent holds backfilled Tweet IDs not yet received from the stream
+	sent map[int64]struct{}
+}
+
+// newStreamBackfill returns a streamBackfill which searches for Tweets
+// matching the Track predicates in the given params.
+func newStreamBackfill(search *SearchService, params *StreamFilterParams) *streamBackfill {
+	return &streamBackfill{
+		search:  search,
+		queries: backfillQueries(params),
+	}
+}
+
+// newResumedStreamBackfill returns a streamBackfill which also searches for
+// Tweets since the ID saved in the Checkpoint when the stream first connects.
+func newResumedStreamBackfill(search *SearchService, params *StreamFilterParams, checkpoint Checkpoint, key string) (*streamBackfill, error) {
+	sinceID, err := checkpoint.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	b := newStreamBackfill(search, params)
+	b.sinceID = sinceID
+	b.resume = sinceID != 0
+	return b, nil
+}
+
+// resuming returns true the first time it is called for a resumed stream,
+// false otherwise.
+func (b *streamBackfill) resuming() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	resume := b.resume
+	b.resume = false
+	return resume
+}
+
+// update changes the Track predicates to search for.
+func (b *streamBackfill) update(params *StreamFilterParams) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queries = backfillQueries(params)
+}
+
+// duplicate records a Tweet received from the stream. Returns true if the
+// Tweet was already sent as a backfilled Tweet, false otherwise.
+func (b *streamBackfill) duplicate(tweet *Tweet) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if tweet.ID > b.sinceID {
+		b.sinceID = tweet.ID
+	}
+	if _, ok := b.sent[tweet.ID]; ok {
+		delete(b.sent, tweet.ID)
+		return true
+	}
+	return false
+}
+
+// fetch searches for Tweets newer than the highest Tweet ID received and
+// returns them oldest first, marked as Backfilled. Returns no Tweets if none
+// have been received yet, since there is no position to backfill from. If a
+// search fails, the Tweets found by the other searches are returned with the
+// first error.
+func (b *streamBackfill) fetch() ([]*Tweet, error) {
+	b.mu.Lock()
+	queries, sinceID := b.queries, b.sinceID
+	b.mu.Unlock()
+	if len(queries) == 0 || sinceID == 0 {
+		return nil, nil
+	}
+	var tweets []*Tweet
+	var firstErr error
+	found := make(map[int64]bool)
+	for _, query := range queries {
+		results, err := b.searchSince(query, sinceID)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		// a Tweet may match the phrases of more than one query
+		for _, tweet := range results {
+			if !found[tweet.ID] {
+				found[tweet.ID] = true
+				tweets = append(tweets, tweet)
+			}
+		}
+	}
+	sort.Sort(tweetsByID(tweets))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Tweets sent by earlier backfills may not have been received yet
+	if b.sent == nil {
+		b.sent = make(map[int64]struct{})
+	}
+	for _, tweet := range tweets {
+		tweet.Backfilled = true
+		b.sent[tweet.ID] = struct{}{}
+		if tweet.ID > b.sinceID {
+			b.sinceID = tweet.ID
+		}
+	}
+	return tweets, firstErr
+}
+
+// searchSince returns the Tweets matching the query which are newer than
+// sinceID, paging back through the results.
+func (b *streamBackfill) searchSince(query string, sinceID int64) ([]*Tweet, error) {
+	var tweets []*Tweet
+	var maxID int64
+	for {
+		params := &SearchParams{
+			Query:      query,
+			ResultType: "recent",
+			Count:      backfillCount,
+			SinceID:    sinceID,
+			MaxID:      maxID,
+		}
+		search, _, err := b.search.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(search.Statuses) == 0 {
+			break
+		}
+		for _, tweet := range search.Statuses {
+			if tweet.ID > sinceID {
+				tweets = append(tweets, tweet)
+			}
+		}
+		// results are newest first, page back with max_id until caught up
+		next := search.Statuses[len(search.Statuses)-1].ID - 1
+		if next <= sinceID || (maxID != 0 && next >= maxID) {
+			break
+		}
+		maxID = next
+	}
+	return tweets, nil
+}
+
+// backfillQueries returns search queries which together are equivalent to
+// the Track predicates. Words of a phrase must all match, any phrase may
+// match. Phrases are split across as few queries as fit the search query
+// limit. Phrases too long to search on their own are not backfilled.
+func backfillQueries(params *StreamFilterParams) []string {
+	if params == nil {
+		return nil
+	}
+	var queries []string
+	var phrases []string
+	for _, phrase := range splitPredicates(params.Track) {
+		if strings.Contains(phrase, " ") {
+			phrase = "(" + phrase + ")"
+		}
+		if len(url.QueryEscape(phrase)) > maxSearchQueryLength {
+			continue
+		}
+		if len(phrases) > 0 && len(url.QueryEscape(strings.Join(append(phrases, phrase), " OR "))) > maxSearchQueryLength {
+			queries = append(queries, strings.Join(phrases, " OR "))
+			phrases = nil
+		}
+		phrases = append(phrases, phrase)
+	}
+	if len(phrases) > 0 {
+		queries = append(queries, strings.Join(phrases, " OR "))
+	}
+	return queries
+}
+
+// tweetsByID sorts Tweets by ascending ID.
+type tweetsByID []*Tweet
+
+func (t tweetsByID) Len() int           { return len(t) }
+func (t tweetsByID) Less(i, j int) bool { return t[i].ID < t[j].ID }
+func (t tweetsByID) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
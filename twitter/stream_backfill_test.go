@@ -0,0 +1,212 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_FilterBackfill(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	reqCount := 0
+	mux.HandleFunc("/1.1/statuses/filter.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		switch reqCount {
+		case 0:
+			fmt.Fprintf(w, `{"id": 10, "retweet_count": 0}`+"\r\n")
+		case 1:
+			fmt.Fprintf(w, `{"id": 12, "retweet_count": 0}`+"\r\n"+`{"id": 13, "retweet_count": 0}`+"\r\n")
+		default:
+			http.Error(w, "Not Found", 404)
+		}
+		reqCount++
+	})
+	searchCount := 0
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assertQuery(t, map[string]string{"q": "gophercon OR (golang talks)", "result_type": "recent", "count": "100", "since_id": "10"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"statuses": [{"id": 12}, {"id": 11}]}`)
+		searchCount++
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.FilterBackfill(&StreamFilterParams{
+		Track: []string{"gophercon", "golang talks"},
+	})
+	assert.NoError(t, err)
+	defer stream.Stop()
+	var ids []int64
+	var backfilled []bool
+	for message := range stream.Messages {
+		tweet := message.(*Tweet)
+		ids = append(ids, tweet.ID)
+		backfilled = append(backfilled, tweet.Backfilled)
+	}
+	assert.Equal(t, []int64{10, 11, 12, 13}, ids)
+	assert.Equal(t, []bool{false, true, true, false}, backfilled)
+	assert.Equal(t, 1, searchCount)
+}
+
+func TestStreamBackfill_Fetch(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("max_id") {
+		case "":
+			fmt.Fprintf(w, `{"statuses": [{"id": 25}, {"id": 24}]}`)
+		case "23":
+			fmt.Fprintf(w, `{"statuses": [{"id": 23}, {"id": 22}]}`)
+		case "21":
+			fmt.Fprintf(w, `{"statuses": []}`)
+		default:
+			t.Errorf("unexpected max_id %s", r.URL.Query().Get("max_id"))
+		}
+	})
+
+	client := NewClient(httpClient)
+	backfill := newStreamBackfill(client.Search, &StreamFilterParams{Track: []string{"gopher"}})
+	// nothing to backfill until a Tweet has been received
+	tweets, err := backfill.fetch()
+	assert.NoError(t, err)
+	assert.Empty(t, tweets)
+
+	assert.False(t, backfill.duplicate(&Tweet{ID: 20}))
+	tweets, err = backfill.fetch()
+	assert.NoError(t, err)
+	var ids []int64
+	for _, tweet := range tweets {
+		assert.True(t, tweet.Backfilled)
+		ids = append(ids, tweet.ID)
+	}
+	assert.Equal(t, []int64{22, 23, 24, 25}, ids)
+	// backfilled Tweets are duplicates once
+	assert.True(t, backfill.duplicate(&Tweet{ID: 23}))
+	assert.False(t, backfill.duplicate(&Tweet{ID: 23}))
+	assert.False(t, backfill.duplicate(&Tweet{ID: 26}))
+
+	// Tweets sent by an earlier backfill are still duplicates after another
+	tweets, err = backfill.fetch()
+	assert.NoError(t, err)
+	assert.Empty(t, tweets)
+	assert.True(t, backfill.duplicate(&Tweet{ID: 24}))
+}
+
+func TestBackfillQueries(t *testing.T) {
+	cases := []struct {
+		params   *StreamFilterParams
+		expected []string
+	}{
+		{nil, nil},
+		{&StreamFilterParams{Follow: []string{"623265148"}}, nil},
+		{&StreamFilterParams{Track: []string{"golang"}}, []string{"golang"}},
+		{&StreamFilterParams{Track: []string{"gophercon,golang talks", "go"}}, []string{"gophercon OR (golang talks) OR go"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, backfillQueries(c.params))
+	}
+}
+
+func TestBackfillQueries_Limit(t *testing.T) {
+	var track []string
+	for i := 0; i < 100; i++ {
+		track = append(track, fmt.Sprintf("gopher%d talks", i))
+	}
+	queries := backfillQueries(&StreamFilterParams{Track: track})
+	assert.True(t, len(queries) > 1)
+	var phrases []string
+	for _, query := range queries {
+		assert.True(t, len(url.QueryEscape(query)) <= maxSearchQueryLength, query)
+		phrases = append(phrases, strings.Split(query, " OR ")...)
+	}
+	// every phrase is searched once
+	assert.Len(t, phrases, len(track))
+	assert.Equal(t, "(gopher0 talks)", phrases[0])
+	assert.Equal(t, "(gopher99 talks)", phrases[len(phrases)-1])
+
+	// a phrase too long to search is skipped
+	long := strings.Repeat("gopher ", 80)
+	queries = backfillQueries(&StreamFilterParams{Track: []string{"golang", long, "gophercon"}})
+	assert.Equal(t, []string{"golang OR gophercon"}, queries)
+	assert.Nil(t, backfillQueries(&StreamFilterParams{Track: []string{long}}))
+}
+
+func TestStreamBackfill_FetchQueries(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("q") {
+		case "gopher":
+			fmt.Fprintf(w, `{"statuses": [{"id": 23}, {"id": 21}]}`)
+		case "golang":
+			fmt.Fprintf(w, `{"statuses": [{"id": 23}, {"id": 22}]}`)
+		default:
+			w.WriteHeader(400)
+			fmt.Fprintf(w, `{"errors": [{"code": 195, "message": "Missing or invalid url parameter."}]}`)
+		}
+	})
+
+	client := NewClient(httpClient)
+	backfill := newStreamBackfill(client.Search, nil)
+	backfill.queries = []string{"gopher", "rust", "golang"}
+	backfill.duplicate(&Tweet{ID: 20})
+	tweets, err := backfill.fetch()
+	// Tweets of the other queries are returned with the error
+	assert.Equal(t, APIError{Errors: []ErrorDetail{ErrorDetail{Code: 195, Message: "Missing or invalid url parameter."}}}, err)
+	var ids []int64
+	for _, tweet := range tweets {
+		ids = append(ids, tweet.ID)
+	}
+	assert.Equal(t, []int64{21, 22, 23}, ids)
+}
+
+func TestStream_FilterResume(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	reqCount := 0
+	mux.HandleFunc("/1.1/statuses/filter.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		switch reqCount {
+		case 0:
+			fmt.Fprintf(w, `{"id": 12, "retweet_count": 0}`+"\r\n"+`{"id": 13, "retweet_count": 0}`+"\r\n")
+		default:
+			http.Error(w, "Not Found", 404)
+		}
+		reqCount++
+	})
+	mux.HandleFunc("/1.1/search/tweets.json", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, map[string]string{"q": "gophercon", "result_type": "recent", "count": "100", "since_id": "10"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"statuses": [{"id": 12}, {"id": 11}]}`)
+	})
+
+	client := NewClient(httpClient)
+	checkpoint := NewMemoryCheckpoint()
+	checkpoint.Save("gophercon", 10)
+	stream, err := client.Streams.FilterResume(&StreamFilterParams{Track: []string{"gophercon"}}, checkpoint, "gophercon")
+	assert.NoError(t, err)
+	defer stream.Stop()
+	var ids []int64
+	for message := range stream.Messages {
+		ids = append(ids, message.(*Tweet).ID)
+	}
+	assert.Equal(t, []int64{11, 12, 13}, ids)
+
+	_, err = client.Streams.FilterResume(&StreamFilterParams{Track: []string{"gophercon"}}, failingCheckpoint{}, "gophercon")
+	assert.EqualError(t, err, "load failed")
+}
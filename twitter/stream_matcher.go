@@ -0,0 +1,282 @@
+package twitter
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter predicate names used in FilterRule.
+const (
+	TrackPredicate     = "track"
+	FollowPredicate    = "follow"
+	LocationsPredicate = "locations"
+)
+
+// FilterRule is a single predicate from StreamFilterParams. Value is the Track
+// phrase, Follow user ID, or Locations bounding box ("sw_lng,sw_lat,ne_lng,
+// ne_lat") as given in the params.
+type FilterRule struct {
+	Predicate string
+	Value     string
+}
+
+// FilterMatcher matches Tweets against the predicates of StreamFilterParams
+// using Twitter's documented matching rules. The filter stream does not say
+// which predicate a Tweet matched, so a FilterMatcher can be used to find out.
+// https://dev.twitter.com/streaming/overview/request-parameters
+type FilterMatcher struct {
+	track     []trackPhrase
+	follow    map[int64]string
+	locations []locationBox
+	language  map[string]bool
+}
+
+// trackPhrase is a Track phrase whose words must all appear in a Tweet.
+type trackPhrase struct {
+	value string
+	words []string
+}
+
+// locationBox is a Locations bounding box.
+type locationBox struct {
+	value  string
+	bounds Bounds
+}
+
+// NewFilterMatcher returns a FilterMatcher for the given StreamFilterParams.
+// Returns an error if a Follow ID is not an integer or if Locations is not a
+// list of longitude, latitude pairs for the corners of bounding boxes.
+func NewFilterMatcher(params *StreamFilterParams) (*FilterMatcher, error) {
+	if params == nil {
+		params = &StreamFilterParams{}
+	}
+	m := &FilterMatcher{
+		follow: make(map[int64]string),
+	}
+	for _, phrase := range splitPredicates(params.Track) {
+		words := strings.Fields(strings.ToLower(phrase))
+		if len(words) > 0 {
+			m.track = append(m.track, trackPhrase{value: phrase, words: words})
+		}
+	}
+	for _, id := range splitPredicates(params.Follow) {
+		userID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("twitter: invalid follow id %q", id)
+		}
+		m.follow[userID] = id
+	}
+	locations, err := parseLocations(params.Locations)
+	if err != nil {
+		return nil, err
+	}
+	m.locations = locations
+	if len(params.Language) > 0 {
+		m.language = make(map[string]bool)
+		for _, lang := range splitPredicates(params.Language) {
+			m.language[strings.ToLower(lang)] = true
+		}
+	}
+	return m, nil
+}
+
+// Match returns the rules which the Tweet matches, or nil if it matches none.
+// Track phrases are matched against the text, hashtags, mentions, and URLs of
+// the Tweet and of any retweeted or quoted Tweet. Follow IDs match Tweets by
+// the user, retweets of their Tweets, and replies to their Tweets. Locations
+// match a Tweet's coordinates or, without coordinates, any overlap with its
+// Place. Tweets in a language not listed in Language match nothing.
+func (m *FilterMatcher) Match(tweet *Tweet) []FilterRule {
+	if tweet == nil {
+		return nil
+	}
+	if m.language != nil && !m.language[strings.ToLower(tweet.Lang)] {
+		return nil
+	}
+	var rules []FilterRule
+	if len(m.track) > 0 {
+		tokens := make(map[string]bool)
+		addTweetTokens(tokens, tweet)
+		if tweet.RetweetedStatus != nil {
+			addTweetTokens(tokens, tweet.RetweetedStatus)
+		}
+		if tweet.QuotedStatus != nil {
+			addTweetTokens(tokens, tweet.QuotedStatus)
+		}
+		for _, phrase := range m.track {
+			if phrase.matches(tokens) {
+				rules = append(rules, FilterRule{TrackPredicate, phrase.value})
+			}
+		}
+	}
+	for _, userID := range followedUserIDs(tweet) {
+		if id, ok := m.follow[userID]; ok {
+			rules = append(rules, FilterRule{FollowPredicate, id})
+		}
+	}
+	for _, box := range m.locations {
+		if box.matches(tweet) {
+			rules = append(rules, FilterRule{LocationsPredicate, box.value})
+		}
+	}
+	return rules
+}
+
+// matches returns true if every word of the phrase is among the tokens.
+func (p trackPhrase) matches(tokens map[string]bool) bool {
+	for _, word := range p.words {
+		if !tokens[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the Tweet coordinates are inside the box or, if the
+// Tweet has no coordinates, if its Place bounding box overlaps the box.
+func (b locationBox) matches(tweet *Tweet) bool {
+	if tweet.Coordinates != nil {
+		return b.bounds.Contains(tweet.Coordinates.Point())
+	}
+	if tweet.Place == nil || tweet.Place.BoundingBox == nil {
+		return false
+	}
+	placeBounds, ok := tweet.Place.BoundingBox.Bounds()
+	return ok && b.bounds.Intersects(placeBounds)
+}
+
+// followedUserIDs returns the IDs of users a Tweet is delivered for on a
+// Follow predicate: the author, the author of a retweeted Tweet, and the user
+// replied to using the reply button.
+func followedUserIDs(tweet *Tweet) []int64 {
+	var ids []int64
+	if tweet.User != nil {
+		ids = append(ids, tweet.User.ID)
+	}
+	if tweet.RetweetedStatus != nil && tweet.RetweetedStatus.User != nil {
+		ids = append(ids, tweet.RetweetedStatus.User.ID)
+	}
+	// manual replies have no in_reply_to_status_id and are not delivered
+	if tweet.InReplyToStatusID != 0 && tweet.InReplyToUserID != 0 {
+		ids = append(ids, tweet.InReplyToUserID)
+	}
+	return ids
+}
+
+// addTweetTokens adds the lowercase words which Track phrases match in a
+// Tweet, using the untruncated text of extended Tweets. Punctuation in the
+// text is ignored, but words with punctuation are also kept as-is so that
+// Track words containing punctuation match exactly.
+func addTweetTokens(tokens map[string]bool, tweet *Tweet) {
+	for _, field := range strings.Fields(html.UnescapeString(tweet.FullTextOrText())) {
+		field = strings.ToLower(field)
+		tokens[field] = true
+		if field[0] == '#' || field[0] == '@' {
+			// #hashtag and @mention match without the symbol, but #newtwitter
+			// or @twitter's do not match twitter's
+			if name := leadingWord(field[1:]); name != "" {
+				tokens[name] = true
+			}
+			continue
+		}
+		if word := strings.TrimFunc(field, isPunct); word != "" {
+			tokens[word] = true
+		}
+	}
+	if entities := tweet.FullEntities(); entities != nil {
+		for _, hashtag := range entities.Hashtags {
+			tokens[strings.ToLower(hashtag.Text)] = true
+		}
+		for _, mention := range entities.UserMentions {
+			tokens[strings.ToLower(mention.ScreenName)] = true
+		}
+		for _, u := range entities.Urls {
+			addURLTokens(tokens, u)
+		}
+		for _, media := range entities.Media {
+			addURLTokens(tokens, media.URLEntity)
+		}
+	}
+}
+
+// addURLTokens adds the expanded and display forms of a URL and the words in
+// it, since URLs are split at punctuation for matching.
+func addURLTokens(tokens map[string]bool, entity URLEntity) {
+	for _, rawURL := range []string{entity.ExpandedURL, entity.DisplayURL} {
+		if rawURL == "" {
+			continue
+		}
+		rawURL = strings.ToLower(rawURL)
+		tokens[rawURL] = true
+		if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+			tokens[u.Host] = true
+			tokens[strings.TrimPrefix(u.Host, "www.")] = true
+			tokens[u.Host+u.Path] = true
+		}
+		for _, word := range strings.FieldsFunc(rawURL, isPunct) {
+			tokens[word] = true
+		}
+	}
+}
+
+// leadingWord returns the leading letters, digits, and underscores of s.
+func leadingWord(s string) string {
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// isPunct returns true for runes which are not letters or digits.
+func isPunct(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// splitPredicates splits comma separated predicate values, trimming space and
+// dropping empty values. Params may give one value per element or a comma
+// separated list, as both are sent the same way.
+func splitPredicates(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				split = append(split, v)
+			}
+		}
+	}
+	return split
+}
+
+// parseLocations parses a Locations predicate into bounding boxes.
+func parseLocations(locations []string) ([]locationBox, error) {
+	values := splitPredicates(locations)
+	if len(values)%4 != 0 {
+		return nil, fmt.Errorf("twitter: locations must be sets of 4 coordinates, got %d", len(values))
+	}
+	var boxes []locationBox
+	for i := 0; i < len(values); i += 4 {
+		var coords [4]float64
+		for j := range coords {
+			f, err := strconv.ParseFloat(values[i+j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("twitter: invalid location coordinate %q", values[i+j])
+			}
+			coords[j] = f
+		}
+		bounds, err := NewBounds(Point{Lng: coords[0], Lat: coords[1]}, Point{Lng: coords[2], Lat: coords[3]})
+		if err != nil {
+			return nil, err
+		}
+		boxes = append(boxes, locationBox{
+			value:  strings.Join(values[i:i+4], ","),
+			bounds: bounds,
+		})
+	}
+	return boxes, nil
+}
@@ -0,0 +1,154 @@
+package twitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFilterMatcher_Errors(t *testing.T) {
+	_, err := NewFilterMatcher(&StreamFilterParams{Follow: []string{"dghubble"}})
+	assert.EqualError(t, err, `twitter: invalid follow id "dghubble"`)
+	_, err = NewFilterMatcher(&StreamFilterParams{Locations: []string{"-122.75", "36.8", "-121.75"}})
+	assert.EqualError(t, err, "twitter: locations must be sets of 4 coordinates, got 3")
+	_, err = NewFilterMatcher(&StreamFilterParams{Locations: []string{"-122.75,36.8,-121.75,north"}})
+	assert.EqualError(t, err, `twitter: invalid location coordinate "north"`)
+	_, err = NewFilterMatcher(&StreamFilterParams{Locations: []string{"-121.75", "37.8", "-122.75", "36.8"}})
+	assert.EqualError(t, err, "twitter: bounds -121.75,37.8,-122.75,36.8 must list the south west corner before the north east corner")
+}
+
+func TestFilterMatcher_MatchTrack(t *testing.T) {
+	matcher, err := NewFilterMatcher(&StreamFilterParams{
+		Track: []string{"Twitter", "twitter api,twitter streaming", "example com", "hello."},
+	})
+	assert.NoError(t, err)
+	cases := []struct {
+		tweet    *Tweet
+		expected []string
+	}{
+		{&Tweet{Text: "TWITTER"}, []string{"Twitter"}},
+		{&Tweet{Text: `I "like" Twitter.`}, []string{"Twitter"}},
+		{&Tweet{Text: "#twitter and @twitter's office"}, []string{"Twitter"}},
+		{&Tweet{Text: "TwitterTracker and #newtwitter"}, nil},
+		{&Tweet{Text: "The Twitter API is awesome"}, []string{"Twitter", "twitter api"}},
+		{&Tweet{Text: "Twitter has a streaming API"}, []string{"Twitter", "twitter api", "twitter streaming"}},
+		{&Tweet{Text: "my brother says hello."}, []string{"hello."}},
+		{&Tweet{Text: "hello world"}, nil},
+		{&Tweet{Text: "Tom &amp; Jerry"}, nil},
+		{
+			&Tweet{
+				Text: "look https://t.co/abc",
+				Entities: &Entities{Urls: []URLEntity{
+					{ExpandedURL: "http://www.example.com/foo", DisplayURL: "example.com/foo"},
+				}},
+			},
+			[]string{"example com"},
+		},
+		{
+			&Tweet{
+				Text:     "RT @gopher: nothing",
+				Entities: &Entities{UserMentions: []MentionEntity{{ScreenName: "gopher"}}},
+				RetweetedStatus: &Tweet{
+					Text:     "#Twitter",
+					Entities: &Entities{Hashtags: []HashtagEntity{{Text: "Twitter"}}},
+				},
+			},
+			[]string{"Twitter"},
+		},
+		{&Tweet{Text: "nothing", QuotedStatus: &Tweet{Text: "twitter"}}, []string{"Twitter"}},
+	}
+	for _, c := range cases {
+		var phrases []string
+		for _, rule := range matcher.Match(c.tweet) {
+			assert.Equal(t, TrackPredicate, rule.Predicate)
+			phrases = append(phrases, rule.Value)
+		}
+		assert.Equal(t, c.expected, phrases, c.tweet.Text)
+	}
+}
+
+func TestFilterMatcher_MatchFollow(t *testing.T) {
+	matcher, err := NewFilterMatcher(&StreamFilterParams{Follow: []string{"623265148", "113419064"}})
+	assert.NoError(t, err)
+	dghubble := &User{ID: 623265148}
+	golang := &User{ID: 113419064}
+	other := &User{ID: 20}
+	cases := []struct {
+		tweet    *Tweet
+		expected []FilterRule
+	}{
+		{&Tweet{User: dghubble}, []FilterRule{{FollowPredicate, "623265148"}}},
+		{&Tweet{User: other}, nil},
+		{
+			&Tweet{User: other, RetweetedStatus: &Tweet{User: golang}},
+			[]FilterRule{{FollowPredicate, "113419064"}},
+		},
+		{
+			&Tweet{User: golang, InReplyToUserID: 623265148, InReplyToStatusID: 42},
+			[]FilterRule{{FollowPredicate, "113419064"}, {FollowPredicate, "623265148"}},
+		},
+		// manual replies and mentions are not matched
+		{&Tweet{User: other, InReplyToUserID: 623265148}, nil},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, matcher.Match(c.tweet))
+	}
+}
+
+func TestFilterMatcher_MatchLocations(t *testing.T) {
+	sanFrancisco := "-122.75,36.8,-121.75,37.8"
+	matcher, err := NewFilterMatcher(&StreamFilterParams{
+		Locations: []string{"-122.75", "36.8", "-121.75", "37.8", "-74", "40", "-73", "41"},
+	})
+	assert.NoError(t, err)
+	cases := []struct {
+		tweet    *Tweet
+		expected []FilterRule
+	}{
+		{&Tweet{Coordinates: &Coordinates{Coordinates: [2]float64{-122.4, 37.7}}}, []FilterRule{{LocationsPredicate, sanFrancisco}}},
+		{&Tweet{Coordinates: &Coordinates{Coordinates: [2]float64{37.7, -122.4}}}, nil},
+		{
+			&Tweet{Place: &Place{BoundingBox: &BoundingBox{Coordinates: [][][2]float64{
+				{{-123, 37.5}, {-123, 38}, {-122.5, 38}, {-122.5, 37.5}},
+			}}}},
+			[]FilterRule{{LocationsPredicate, sanFrancisco}},
+		},
+		// coordinates take precedence over the place
+		{
+			&Tweet{
+				Coordinates: &Coordinates{Coordinates: [2]float64{0, 0}},
+				Place: &Place{BoundingBox: &BoundingBox{Coordinates: [][][2]float64{
+					{{-123, 37.5}, {-123, 38}, {-122.5, 38}, {-122.5, 37.5}},
+				}}},
+			},
+			nil,
+		},
+		{&Tweet{}, nil},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, matcher.Match(c.tweet))
+	}
+}
+
+func TestFilterMatcher_MatchLanguage(t *testing.T) {
+	matcher, err := NewFilterMatcher(&StreamFilterParams{Track: []string{"gopher"}, Language: []string{"en"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []FilterRule{{TrackPredicate, "gopher"}}, matcher.Match(&Tweet{Text: "gopher", Lang: "en"}))
+	assert.Nil(t, matcher.Match(&Tweet{Text: "gopher", Lang: "fr"}))
+	assert.Nil(t, matcher.Match(nil))
+}
+
+func TestFilterMatcher_MatchExtendedTweet(t *testing.T) {
+	matcher, err := NewFilterMatcher(&StreamFilterParams{Track: []string{"gopher", "golang"}})
+	assert.NoError(t, err)
+	tweet := &Tweet{
+		Text: "a truncated Tweet…",
+		ExtendedTweet: &ExtendedTweet{
+			FullText: "a truncated Tweet about gophers",
+			Entities: &Entities{Hashtags: []HashtagEntity{{Text: "golang"}}},
+		},
+	}
+	assert.Equal(t, []FilterRule{{TrackPredicate, "golang"}}, matcher.Match(tweet))
+	tweet.ExtendedTweet.FullText = "a truncated Tweet about a gopher"
+	assert.Equal(t, []FilterRule{{TrackPredicate, "gopher"}, {TrackPredicate, "golang"}}, matcher.Match(tweet))
+}
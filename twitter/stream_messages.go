@@ -1,5 +1,10 @@
 package twitter
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // StatusDeletion indicates that a given Tweet has been deleted.
 // https://dev.twitter.com/streaming/overview/messages-types#status_deletion_notices_delete
 type StatusDeletion struct {
@@ -98,13 +103,101 @@ type directMessageNotice struct {
 	DirectMessage *DirectMessage `json:"direct_message"`
 }
 
+// Event names of user stream Event messages.
+// https://dev.twitter.com/streaming/overview/messages-types#Events_event
+const (
+	AccessRevokedEvent        = "access_revoked"
+	BlockEvent                = "block"
+	UnblockEvent              = "unblock"
+	FavoriteEvent             = "favorite"
+	UnfavoriteEvent           = "unfavorite"
+	FollowEvent               = "follow"
+	UnfollowEvent             = "unfollow"
+	ListCreatedEvent          = "list_created"
+	ListDestroyedEvent        = "list_destroyed"
+	ListUpdatedEvent          = "list_updated"
+	ListMemberAddedEvent      = "list_member_added"
+	ListMemberRemovedEvent    = "list_member_removed"
+	ListUserSubscribedEvent   = "list_user_subscribed"
+	ListUserUnsubscribedEvent = "list_user_unsubscribed"
+	QuotedTweetEvent          = "quoted_tweet"
+	UserUpdateEvent           = "user_update"
+	MuteEvent                 = "mute"
+	UnmuteEvent               = "unmute"
+	FavoritedRetweetEvent     = "favorited_retweet"
+	RetweetedRetweetEvent     = "retweeted_retweet"
+)
+
 // Event is a non-Tweet notification message (e.g. like, retweet, follow).
+// TargetObject is a *Tweet for favorite, unfavorite, quoted_tweet,
+// favorited_retweet, and retweeted_retweet events, a *List for list_* events,
+// and nil otherwise.
 // https://dev.twitter.com/streaming/overview/messages-types#Events_event
 type Event struct {
-	Event     string `json:"event"`
-	CreatedAt string `json:"created_at"`
-	Target    *User  `json:"target"`
-	Source    *User  `json:"source"`
-	// TODO: add List or deprecate it
-	TargetObject *Tweet `json:"target_object"`
+	Event        string      `json:"event"`
+	CreatedAt    string      `json:"created_at"`
+	Target       *User       `json:"target"`
+	Source       *User       `json:"source"`
+	TargetObject interface{} `json:"target_object"`
+}
+
+// CreatedAtTime returns the time the Event occurred.
+func (e Event) CreatedAtTime() (time.Time, error) {
+	return time.Parse(time.RubyDate, e.CreatedAt)
+}
+
+// UnmarshalJSON decodes an Event, decoding the target_object according to
+// the type of event.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	decoded := struct {
+		*event
+		TargetObject json.RawMessage `json:"target_object"`
+	}{event: (*event)(e)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	e.TargetObject = nil
+	if len(decoded.TargetObject) == 0 || string(decoded.TargetObject) == "null" {
+		return nil
+	}
+	var target interface{}
+	switch e.Event {
+	case FavoriteEvent, UnfavoriteEvent, QuotedTweetEvent, FavoritedRetweetEvent, RetweetedRetweetEvent:
+		target = new(Tweet)
+	case ListCreatedEvent, ListDestroyedEvent, ListUpdatedEvent, ListMemberAddedEvent,
+		ListMemberRemovedEvent, ListUserSubscribedEvent, ListUserUnsubscribedEvent:
+		target = new(List)
+	default:
+		return nil
+	}
+	if err := json.Unmarshal(decoded.TargetObject, target); err != nil {
+		return err
+	}
+	e.TargetObject = target
+	return nil
+}
+
+// SiteStreamMessage is a message from a Site Stream for a particular user.
+// Message is decoded like other stream messages (e.g. *Tweet, *Event).
+// https://dev.twitter.com/streaming/overview/messages-types#envelopes_for_user
+type SiteStreamMessage struct {
+	ForUser int64       `json:"for_user"`
+	Message interface{} `json:"message"`
+}
+
+type siteStreamEnvelope struct {
+	ForUser int64           `json:"for_user"`
+	Message json.RawMessage `json:"message"`
+}
+
+// SiteStreamControl is the first message of a Site Stream. Its ControlURI
+// identifies the stream to the StreamService Site control methods.
+// https://dev.twitter.com/streaming/sitestreams/controlstreams
+type SiteStreamControl struct {
+	ControlURI string `json:"control_uri"`
+}
+
+type siteStreamControlNotice struct {
+	Control *SiteStreamControl `json:"control"`
 }
@@ -0,0 +1,69 @@
+package twitter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected *Event
+	}{
+		{
+			`{"event": "favorite", "created_at": "Sat Sep 4 16:10:54 +0000 2010", "source": {"screen_name": "dghubble"}, "target": {"screen_name": "golang"}, "target_object": {"id": 20, "text": "just setting up my twttr"}}`,
+			&Event{
+				Event:        FavoriteEvent,
+				CreatedAt:    "Sat Sep 4 16:10:54 +0000 2010",
+				Source:       &User{ScreenName: "dghubble"},
+				Target:       &User{ScreenName: "golang"},
+				TargetObject: &Tweet{ID: 20, Text: "just setting up my twttr"},
+			},
+		},
+		{
+			`{"event": "list_member_added", "source": {"screen_name": "dghubble"}, "target": {"screen_name": "golang"}, "target_object": {"id": 574, "slug": "gophers", "member_count": 1}}`,
+			&Event{
+				Event:        ListMemberAddedEvent,
+				Source:       &User{ScreenName: "dghubble"},
+				Target:       &User{ScreenName: "golang"},
+				TargetObject: &List{ID: 574, Slug: "gophers", MemberCount: 1},
+			},
+		},
+		{
+			`{"event": "list_created", "target_object": {"id": 574, "name": "Gophers"}}`,
+			&Event{Event: ListCreatedEvent, TargetObject: &List{ID: 574, Name: "Gophers"}},
+		},
+		{
+			`{"event": "follow", "source": {"screen_name": "dghubble"}, "target": {"screen_name": "golang"}, "target_object": null}`,
+			&Event{Event: FollowEvent, Source: &User{ScreenName: "dghubble"}, Target: &User{ScreenName: "golang"}},
+		},
+		{
+			`{"event": "access_revoked", "target_object": {"id": "11", "name": "App"}}`,
+			&Event{Event: AccessRevokedEvent},
+		},
+	}
+	for _, c := range cases {
+		event := new(Event)
+		err := json.Unmarshal([]byte(c.input), event)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, event)
+	}
+}
+
+func TestEvent_UnmarshalJSONError(t *testing.T) {
+	event := new(Event)
+	err := json.Unmarshal([]byte(`{"event": "favorite", "target_object": {"id": "not a number"}}`), event)
+	assert.Error(t, err)
+}
+
+func TestEvent_CreatedAtTime(t *testing.T) {
+	event := Event{CreatedAt: "Mon Jan 02 15:04:05 +0000 2017"}
+	createdAt, err := event.CreatedAtTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2017, 1, 2, 15, 4, 5, 0, time.UTC), createdAt.UTC())
+	_, err = Event{}.CreatedAtTime()
+	assert.Error(t, err)
+}
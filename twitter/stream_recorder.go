@@ -0,0 +1,150 @@
+package twitter
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StreamSink receives the raw lines read by a Stream, without the "\r\n"
+// delimiter. Keep-alives are empty lines. Streams connected by UpdateFilter
+// overlap, so WriteLine must be safe for concurrent use.
+type StreamSink interface {
+	WriteLine(line []byte) error
+}
+
+// recorderTimeFormat names recorded files so that they sort by creation time.
+const recorderTimeFormat = "20060102T150405.000000000Z"
+
+// crlf is the Streaming API line delimiter.
+var crlf = []byte("\r\n")
+
+// StreamRecorderParams are parameters for NewStreamRecorder.
+type StreamRecorderParams struct {
+	// Dir is the directory recorded files are written to.
+	Dir string
+	// Prefix is the start of recorded file names, which are followed by the
+	// UTC creation time and ".gz". Defaults to "stream".
+	Prefix string
+	// MaxSize rotates to a new file once this many uncompressed bytes have
+	// been written to the current file. 0 does not rotate by size.
+	MaxSize int64
+	// MaxAge rotates to a new file once the current file is this old, checked
+	// as lines are received. 0 does not rotate by age.
+	MaxAge time.Duration
+	// KeepAlives records keep-alive lines as well as messages.
+	KeepAlives bool
+}
+
+// StreamRecorder is a StreamSink which archives the raw lines of a Stream to
+// gzip compressed files in the "\r\n" delimited format sent by the Streaming
+// API, rotating files by size and age. Recorded files can be replayed with
+// NewStreamFromFile. It is safe for concurrent use.
+type StreamRecorder struct {
+	params StreamRecorderParams
+	mu     sync.Mutex
+	file   *os.File
+	gz     *gzip.Writer
+	// size is the number of uncompressed bytes written to the current file
+	size   int64
+	opened time.Time
+	now    func() time.Time
+}
+
+// NewStreamRecorder returns a StreamRecorder with the given params. Files are
+// created as lines are written.
+func NewStreamRecorder(params *StreamRecorderParams) *StreamRecorder {
+	if params == nil {
+		params = &StreamRecorderParams{}
+	}
+	r := &StreamRecorder{
+		params: *params,
+		now:    time.Now,
+	}
+	if r.params.Prefix == "" {
+		r.params.Prefix = "stream"
+	}
+	return r
+}
+
+// WriteLine writes the line followed by "\r\n" to the current file, first
+// rotating to a new file if the current one has reached the MaxSize or
+// MaxAge. Keep-alives are skipped unless KeepAlives is set.
+func (r *StreamRecorder) WriteLine(line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil && r.due() {
+		if err := r.close(); err != nil {
+			return err
+		}
+	}
+	if len(line) == 0 && !r.params.KeepAlives {
+		return nil
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return err
+		}
+	}
+	// the line belongs to the Stream's scanner, do not append to it
+	for _, b := range [][]byte{line, crlf} {
+		n, err := r.gz.Write(b)
+		r.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rotate closes the current file, if any. The next line is written to a new
+// file.
+func (r *StreamRecorder) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.close()
+}
+
+// Close closes the current file, flushing any buffered lines. Call Close
+// after the Stream has stopped so the last file is complete.
+func (r *StreamRecorder) Close() error {
+	return r.Rotate()
+}
+
+// due returns true if the current file has reached the MaxSize or MaxAge.
+func (r *StreamRecorder) due() bool {
+	if r.params.MaxSize > 0 && r.size >= r.params.MaxSize {
+		return true
+	}
+	return r.params.MaxAge > 0 && r.now().Sub(r.opened) >= r.params.MaxAge
+}
+
+// open creates a new file named by the current time.
+func (r *StreamRecorder) open() error {
+	r.opened = r.now()
+	name := fmt.Sprintf("%s-%s.gz", r.params.Prefix, r.opened.UTC().Format(recorderTimeFormat))
+	file, err := os.OpenFile(filepath.Join(r.params.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.gz = gzip.NewWriter(file)
+	r.size = 0
+	return nil
+}
+
+// close flushes and closes the current file, if any.
+func (r *StreamRecorder) close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.gz.Close()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file, r.gz = nil, nil
+	return err
+}
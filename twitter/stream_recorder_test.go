@@ -0,0 +1,150 @@
+package twitter
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readRecorded returns the decompressed contents of the recorded files in dir.
+func readRecorded(t *testing.T, dir string) []string {
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	var contents []string
+	for _, info := range files {
+		file, err := os.Open(filepath.Join(dir, info.Name()))
+		assert.NoError(t, err)
+		gz, err := gzip.NewReader(file)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadAll(gz)
+		assert.NoError(t, err)
+		file.Close()
+		contents = append(contents, string(data))
+	}
+	return contents
+}
+
+func TestStreamService_Tee(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "recorder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	raw := `{"id": 1, "retweet_count": 0}` + "\r\n" + "\r\n" + `{"limit": {"track": 10}}` + "\r\n"
+	reqCount := 0
+	mux.HandleFunc("/1.1/statuses/sample.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		switch reqCount {
+		case 0:
+			fmt.Fprint(w, raw)
+		default:
+			http.Error(w, "Not Found", 404)
+		}
+		reqCount++
+	})
+
+	recorder := NewStreamRecorder(&StreamRecorderParams{Dir: dir, KeepAlives: true})
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Tee(recorder).Sample(nil)
+	assert.NoError(t, err)
+	count := 0
+	for range stream.Messages {
+		count++
+	}
+	stream.Stop()
+	assert.Equal(t, 2, count)
+	assert.NoError(t, recorder.Close())
+	assert.Equal(t, []string{raw}, readRecorded(t, dir))
+
+	// recorded files can be replayed
+	files, _ := ioutil.ReadDir(dir)
+	replay, err := NewStreamFromFile(filepath.Join(dir, files[0].Name()), nil)
+	assert.NoError(t, err)
+	defer replay.Stop()
+	var messages []interface{}
+	for message := range replay.Messages {
+		messages = append(messages, message)
+	}
+	assert.Len(t, messages, 2)
+	assert.Equal(t, &StreamLimit{Track: 10}, messages[1])
+	// Tee does not change the client's StreamService
+	assert.Nil(t, client.Streams.sink)
+}
+
+func TestStreamRecorder_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2017, 1, 2, 15, 4, 5, 0, time.UTC)
+	recorder := NewStreamRecorder(&StreamRecorderParams{Dir: dir, Prefix: "sample", MaxSize: 10, MaxAge: time.Minute})
+	recorder.now = func() time.Time { return now }
+	assert.NoError(t, recorder.WriteLine([]byte("12345")))
+	// keep-alives are skipped
+	assert.NoError(t, recorder.WriteLine(nil))
+	assert.NoError(t, recorder.WriteLine([]byte("67890")))
+	// rotated by size
+	now = now.Add(time.Second)
+	assert.NoError(t, recorder.WriteLine([]byte("a")))
+	// rotated by age
+	now = now.Add(time.Minute)
+	assert.NoError(t, recorder.WriteLine([]byte("b")))
+	assert.NoError(t, recorder.Close())
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	var names []string
+	for _, info := range files {
+		names = append(names, info.Name())
+	}
+	assert.Equal(t, []string{
+		"sample-20170102T150405.000000000Z.gz",
+		"sample-20170102T150406.000000000Z.gz",
+		"sample-20170102T150506.000000000Z.gz",
+	}, names)
+	assert.Equal(t, []string{"12345\r\n67890\r\n", "a\r\n", "b\r\n"}, readRecorded(t, dir))
+}
+
+type failingSink struct{}
+
+func (failingSink) WriteLine(line []byte) error { return errors.New("disk full") }
+
+func TestStreamService_TeeError(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	reqCount := 0
+	mux.HandleFunc("/1.1/statuses/sample.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		switch reqCount {
+		case 0:
+			fmt.Fprintf(w, `{"id": 1, "retweet_count": 0}`+"\r\n")
+		default:
+			http.Error(w, "Not Found", 404)
+		}
+		reqCount++
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Tee(failingSink{}).Sample(nil)
+	assert.NoError(t, err)
+	defer stream.Stop()
+	var messages []interface{}
+	for message := range stream.Messages {
+		messages = append(messages, message)
+	}
+	assert.Len(t, messages, 2)
+	assert.Equal(t, errors.New("disk full"), messages[0])
+	assert.Equal(t, int64(1), messages[1].(*Tweet).ID)
+}
@@ -0,0 +1,122 @@
+package twitter
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// ReplayParams are parameters for NewStreamFromReader.
+type ReplayParams struct {
+	// Speed paces messages by the intervals between their created_at times.
+	// 1 replays in real time, 10 ten times faster, and so on. 0 replays as
+	// fast as possible.
+	Speed float64
+}
+
+// NewStreamFromReader returns a Stream which receives the messages of a
+// captured stream from the reader. The capture is "\r\n" delimited, as sent
+// by the Streaming API, and messages are decoded like those of a connected
+// Stream. The Messages channel is closed at the end of the capture. If the
+// reader is an io.ReadCloser, it is closed when the Stream stops.
+func NewStreamFromReader(r io.Reader, params *ReplayParams) *Stream {
+	s := &Stream{
+		Messages: make(chan interface{}),
+		done:     make(chan struct{}),
+		group:    &sync.WaitGroup{},
+	}
+	if params != nil && params.Speed > 0 {
+		s.pacer = &replayPacer{speed: params.Speed}
+	}
+	body, ok := r.(io.ReadCloser)
+	if !ok {
+		body = ioutil.NopCloser(r)
+	}
+	c := s.newConn()
+	s.setBody(c, body)
+	s.group.Add(1)
+	go func() {
+		defer s.removeConn(c)
+		defer s.group.Done()
+		s.receive(c, body)
+	}()
+	return s
+}
+
+// NewStreamFromFile returns a Stream which receives the messages of a
+// captured stream from the named file, like NewStreamFromReader. Gzip
+// compressed files, such as those written by a StreamRecorder, are
+// decompressed.
+func NewStreamFromFile(name string, params *ReplayParams) (*Stream, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewReader(file)
+	var r io.Reader = buf
+	if magic, _ := buf.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		r = gz
+	}
+	// close the file when the Stream stops
+	body := struct {
+		io.Reader
+		io.Closer
+	}{r, file}
+	return NewStreamFromReader(body, params), nil
+}
+
+// replayPacer delays replayed messages so they are sent at the intervals
+// between their created_at times, divided by the speed. Messages without a
+// created_at time are not delayed.
+type replayPacer struct {
+	speed float64
+	// first is the created_at time of the first timed message and start is
+	// when it was sent
+	first time.Time
+	start time.Time
+}
+
+// wait blocks until the message is due to be sent or the done channel
+// receives.
+func (p *replayPacer) wait(message interface{}, done <-chan struct{}) {
+	createdAt, ok := messageTime(message)
+	if !ok {
+		return
+	}
+	if p.first.IsZero() {
+		p.first, p.start = createdAt, time.Now()
+		return
+	}
+	offset := time.Duration(float64(createdAt.Sub(p.first)) / p.speed)
+	if d := p.start.Add(offset).Sub(time.Now()); d > 0 {
+		sleepOrDone(d, done)
+	}
+}
+
+// messageTime returns the created_at time of a stream message, if it has one.
+func messageTime(message interface{}) (time.Time, bool) {
+	var createdAt time.Time
+	var err error
+	switch m := message.(type) {
+	case *Tweet:
+		createdAt, err = m.CreatedAtTime()
+	case *DirectMessage:
+		createdAt, err = m.CreatedAtTime()
+	case *Event:
+		createdAt, err = m.CreatedAtTime()
+	case *SiteStreamMessage:
+		return messageTime(m.Message)
+	default:
+		return createdAt, false
+	}
+	return createdAt, err == nil
+}
@@ -0,0 +1,77 @@
+package twitter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const replayCapture = `{"id": 1, "retweet_count": 0, "created_at": "Mon Jan 02 15:04:05 +0000 2017"}` + "\r\n" +
+	"\r\n" +
+	`{"delete": {"status": {"id": 1, "user_id": 10}}}` + "\r\n" +
+	`{"id": 2, "retweet_count": 0, "created_at": "Mon Jan 02 15:04:06 +0000 2017"}` + "\r\n" +
+	`{"id": 3, "retweet_count": 0, "created_at": "Mon Jan 02 15:04:07 +0000 2017"}` + "\r\n"
+
+func TestNewStreamFromReader(t *testing.T) {
+	stream := NewStreamFromReader(strings.NewReader(replayCapture), nil)
+	defer stream.Stop()
+	var messages []interface{}
+	for message := range stream.Messages {
+		messages = append(messages, message)
+	}
+	assert.Len(t, messages, 4)
+	assert.Equal(t, int64(1), messages[0].(*Tweet).ID)
+	assert.Equal(t, &StatusDeletion{ID: 1, UserID: 10}, messages[1])
+	assert.Equal(t, int64(3), messages[3].(*Tweet).ID)
+}
+
+func TestNewStreamFromReader_Speed(t *testing.T) {
+	// Tweets are 1 second apart, replayed 20 times faster
+	start := time.Now()
+	stream := NewStreamFromReader(strings.NewReader(replayCapture), &ReplayParams{Speed: 20})
+	defer stream.Stop()
+	count := 0
+	for range stream.Messages {
+		count++
+	}
+	assert.Equal(t, 4, count)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestNewStreamFromReader_Stop(t *testing.T) {
+	stream := NewStreamFromReader(strings.NewReader(replayCapture), &ReplayParams{Speed: 1})
+	<-stream.Messages
+	<-stream.Messages
+	// the next Tweet is due in 1 second, Stop must not wait for it
+	done := make(chan struct{})
+	go func() {
+		stream.Stop()
+		close(done)
+	}()
+	assertDone(t, done, 500*time.Millisecond)
+}
+
+func TestNewStreamFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.txt")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(replayCapture), 0600))
+
+	stream, err := NewStreamFromFile(path, nil)
+	assert.NoError(t, err)
+	defer stream.Stop()
+	count := 0
+	for range stream.Messages {
+		count++
+	}
+	assert.Equal(t, 4, count)
+
+	_, err = NewStreamFromFile(filepath.Join(dir, "missing.txt"), nil)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -3,8 +3,11 @@ package twitter
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,25 +22,49 @@ const (
 	siteStream   = "https://sitestream.twitter.com/1.1/"
 )
 
+// filterOverlap is how long UpdateFilter receives from both the old and new
+// filter connections.
+var filterOverlap = 5 * time.Second
+
+// ErrStreamStopped is returned when updating a Stream which has stopped.
+var ErrStreamStopped = errors.New("twitter: stream is stopped")
+
+// ErrNotFilterStream is returned when updating the filter predicates of a
+// Stream which was not started by Filter, FilterBackfill, or FilterResume.
+var ErrNotFilterStream = errors.New("twitter: stream is not a filter stream")
+
 // StreamService provides methods for accessing the Twitter Streaming API.
 type StreamService struct {
 	client *http.Client
 	public *sling.Sling
 	user   *sling.Sling
 	site   *sling.Sling
+	search *SearchService
+	sink   StreamSink
 }
 
 // newStreamService returns a new StreamService.
-func newStreamService(client *http.Client, sling *sling.Sling) *StreamService {
+func newStreamService(client *http.Client, sling *sling.Sling, search *SearchService) *StreamService {
 	sling.Set("User-Agent", userAgent)
 	return &StreamService{
 		client: client,
 		public: sling.New().Base(publicStream).Path("statuses/"),
 		user:   sling.New().Base(userStream),
 		site:   sling.New().Base(siteStream),
+		search: search,
 	}
 }
 
+// Tee returns a copy of the StreamService whose Streams also write each raw
+// line received to the given StreamSink, such as a StreamRecorder. Lines are
+// written before they are decoded, exactly as received. Sink errors are sent
+// on the Messages channel, but do not stop the Stream.
+func (srv *StreamService) Tee(sink StreamSink) *StreamService {
+	tee := *srv
+	tee.sink = sink
+	return &tee
+}
+
 // StreamFilterParams are parameters for StreamService.Filter.
 type StreamFilterParams struct {
 	FilterLevel   string   `url:"filter_level,omitempty"`
@@ -55,7 +82,68 @@ func (srv *StreamService) Filter(params *StreamFilterParams) (*Stream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newStream(srv.client, req), nil
+	return newFilterStream(srv.client, req, nil, srv.sink), nil
+}
+
+// FilterBackfill returns messages that match one or more filter predicates,
+// like Filter. In addition, the highest Tweet ID received is recorded and,
+// when the stream reconnects, Tweets missed while disconnected are found by
+// searching for the Track predicates since that ID. The backfilled Tweets are
+// sent on Messages, oldest first, with Backfilled set and are not sent again
+// if they are also received from the stream. Track lists too long for one
+// search query are searched in parts. Follow and Locations predicates are not
+// backfilled.
+func (srv *StreamService) FilterBackfill(params *StreamFilterParams) (*Stream, error) {
+	req, err := srv.public.New().Post("filter.json").QueryStruct(params).Request()
+	if err != nil {
+		return nil, err
+	}
+	return newFilterStream(srv.client, req, newStreamBackfill(srv.search, params), srv.sink), nil
+}
+
+// FilterResume returns messages that match one or more filter predicates,
+// backfilling missed Tweets like FilterBackfill. When the stream first
+// connects, Tweets since the ID saved in the Checkpoint under the given key
+// are backfilled too, so a restarted collector continues where it stopped.
+// Use SaveCheckpoint to save the ID of each Tweet once handled. Only Track
+// predicates are backfilled, as search cannot match Follow user IDs or
+// Locations, so Tweets which only match those are not resumed.
+func (srv *StreamService) FilterResume(params *StreamFilterParams, checkpoint Checkpoint, key string) (*Stream, error) {
+	req, err := srv.public.New().Post("filter.json").QueryStruct(params).Request()
+	if err != nil {
+		return nil, err
+	}
+	backfill, err := newResumedStreamBackfill(srv.search, params, checkpoint, key)
+	if err != nil {
+		return nil, err
+	}
+	return newFilterStream(srv.client, req, backfill, srv.sink), nil
+}
+
+// UpdateFilter changes the filter predicates of a Stream started by Filter
+// without losing messages in between. A new connection is made with the given
+// params and, once connected, both connections send on the Stream Messages
+// channel for a short overlap, with duplicate Tweets dropped, before the old
+// connection is closed. UpdateFilter blocks until the switchover completes.
+// If the new connection fails, the Stream keeps its current predicates and the
+// error is returned. Concurrent updates are applied one at a time. Returns
+// ErrNotFilterStream for Streams not started by Filter, FilterBackfill, or
+// FilterResume, and ErrStreamStopped if the Stream is stopped.
+func (srv *StreamService) UpdateFilter(stream *Stream, params *StreamFilterParams) error {
+	if !stream.filter {
+		return ErrNotFilterStream
+	}
+	req, err := srv.public.New().Post("filter.json").QueryStruct(params).Request()
+	if err != nil {
+		return err
+	}
+	if err := stream.swap(req, filterOverlap); err != nil {
+		return err
+	}
+	if stream.backfill != nil {
+		stream.backfill.update(params)
+	}
+	return nil
 }
 
 // StreamSampleParams are the parameters for StreamService.Sample.
@@ -70,7 +158,7 @@ func (srv *StreamService) Sample(params *StreamSampleParams) (*Stream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newStream(srv.client, req), nil
+	return newStream(srv.client, req, nil, srv.sink), nil
 }
 
 // StreamUserParams are the parameters for StreamService.User.
@@ -91,7 +179,7 @@ func (srv *StreamService) User(params *StreamUserParams) (*Stream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newStream(srv.client, req), nil
+	return newStream(srv.client, req, nil, srv.sink), nil
 }
 
 // StreamSiteParams are the parameters for StreamService.Site.
@@ -112,7 +200,69 @@ func (srv *StreamService) Site(params *StreamSiteParams) (*Stream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newStream(srv.client, req), nil
+	return newStream(srv.client, req, nil, srv.sink), nil
+}
+
+// StreamSiteUsersParams are the parameters for StreamService.SiteAddUsers
+// and StreamService.SiteRemoveUsers.
+type StreamSiteUsersParams struct {
+	UserID []int64 `url:"user_id,omitempty,comma"`
+}
+
+// SiteAddUsers adds up to 100 users to a running Site Stream, identified by
+// the ControlURI of its SiteStreamControl message.
+// https://dev.twitter.com/streaming/reference/post/site/c/stream_id/add_user
+func (srv *StreamService) SiteAddUsers(controlURI string, userIDs []int64) (*http.Response, error) {
+	params := &StreamSiteUsersParams{UserID: userIDs}
+	apiError := new(APIError)
+	resp, err := srv.site.New().Post(controlPath(controlURI, "add_user.json")).BodyForm(params).Receive(nil, apiError)
+	return resp, relevantError(err, *apiError)
+}
+
+// SiteRemoveUsers removes up to 100 users from a running Site Stream,
+// identified by the ControlURI of its SiteStreamControl message.
+// https://dev.twitter.com/streaming/reference/post/site/c/stream_id/remove_user
+func (srv *StreamService) SiteRemoveUsers(controlURI string, userIDs []int64) (*http.Response, error) {
+	params := &StreamSiteUsersParams{UserID: userIDs}
+	apiError := new(APIError)
+	resp, err := srv.site.New().Post(controlPath(controlURI, "remove_user.json")).BodyForm(params).Receive(nil, apiError)
+	return resp, relevantError(err, *apiError)
+}
+
+// SiteStreamInfo describes a running Site Stream and its users.
+type SiteStreamInfo struct {
+	Users                     []SiteStreamUser `json:"users"`
+	Delimited                 string           `json:"delimited"`
+	IncludeFollowingsActivity string           `json:"include_followings_activity"`
+	IncludeUserChanges        string           `json:"include_user_changes"`
+	Replies                   string           `json:"replies"`
+	With                      string           `json:"with"`
+}
+
+// SiteStreamUser is a user being streamed on a Site Stream.
+type SiteStreamUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	FriendsCount int    `json:"friends_count"`
+}
+
+type siteStreamInfoResponse struct {
+	Info *SiteStreamInfo `json:"info"`
+}
+
+// SiteInfo returns information about a running Site Stream, identified by
+// the ControlURI of its SiteStreamControl message.
+// https://dev.twitter.com/streaming/reference/get/site/c/stream_id/info
+func (srv *StreamService) SiteInfo(controlURI string) (*SiteStreamInfo, *http.Response, error) {
+	info := new(siteStreamInfoResponse)
+	apiError := new(APIError)
+	resp, err := srv.site.New().Get(controlPath(controlURI, "info.json")).Receive(info, apiError)
+	return info.Info, resp, relevantError(err, *apiError)
+}
+
+// controlPath returns the path of a Site Stream control endpoint.
+func controlPath(controlURI, endpoint string) string {
+	return strings.TrimSuffix(controlURI, "/") + "/" + endpoint
 }
 
 // StreamFirehoseParams are the parameters for StreamService.Firehose.
@@ -131,7 +281,7 @@ func (srv *StreamService) Firehose(params *StreamFirehoseParams) (*Stream, error
 	if err != nil {
 		return nil, err
 	}
-	return newStream(srv.client, req), nil
+	return newStream(srv.client, req, nil, srv.sink), nil
 }
 
 // Stream maintains a connection to the Twitter Streaming API, receives
@@ -146,21 +296,73 @@ type Stream struct {
 	Messages chan interface{}
 	done     chan struct{}
 	group    *sync.WaitGroup
-	body     io.Closer
+	// filter is true for Streams whose predicates UpdateFilter may change
+	filter bool
+	// swapMu serializes UpdateFilter switchovers
+	swapMu sync.Mutex
+	// mu guards the stream connections, which change while UpdateFilter
+	// overlaps an old and a new connection, and the Tweet IDs seen meanwhile.
+	mu     sync.Mutex
+	conns  []*streamConn
+	closed bool
+	seen   map[int64]struct{}
+	// backfill searches for Tweets missed while reconnecting, if enabled
+	backfill *streamBackfill
+	// pacer delays messages replayed from a capture, if enabled
+	pacer *replayPacer
+	// sink receives each raw line, if set
+	sink StreamSink
+}
+
+// streamConn is a single connection to a streaming endpoint. A Stream has
+// one, except while UpdateFilter switches from an old connection to a new one.
+type streamConn struct {
+	done chan struct{}
+	once sync.Once
+	body io.Closer
+	// resp is a response received before the retry goroutine started
+	resp *http.Response
+}
+
+// stop signals the connection's retry and receiver to stop.
+func (c *streamConn) stop() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// connect returns the response received before the retry goroutine started,
+// if any. Otherwise, it makes the given http.Request.
+func (c *streamConn) connect(client *http.Client, req *http.Request) (*http.Response, error) {
+	if resp := c.resp; resp != nil {
+		c.resp = nil
+		return resp, nil
+	}
+	return client.Do(req)
 }
 
 // newStream creates a Stream and starts a goroutine to retry connecting and
 // receive from a stream response. The goroutine may stop due to retry errors
 // or be stopped by calling Stop() on the stream.
-func newStream(client *http.Client, req *http.Request) *Stream {
+func newStream(client *http.Client, req *http.Request, backfill *streamBackfill, sink StreamSink) *Stream {
 	s := &Stream{
 		client:   client,
 		Messages: make(chan interface{}),
 		done:     make(chan struct{}),
 		group:    &sync.WaitGroup{},
+		backfill: backfill,
+		sink:     sink,
 	}
 	s.group.Add(1)
-	go s.retry(req, newExponentialBackOff(), newAggressiveExponentialBackOff())
+	go s.retry(s.newConn(), req, newExponentialBackOff(), newAggressiveExponentialBackOff())
+	return s
+}
+
+// newFilterStream creates a Stream like newStream, whose filter predicates
+// can be changed by UpdateFilter.
+func newFilterStream(client *http.Client, req *http.Request, backfill *streamBackfill, sink StreamSink) *Stream {
+	s := newStream(client, req, backfill, sink)
+	s.filter = true
 	return s
 }
 
@@ -168,40 +370,165 @@ func newStream(client *http.Client, req *http.Request) *Stream {
 // blocks until done.
 func (s *Stream) Stop() {
 	close(s.done)
-	// Scanner does not have a Stop() or take a done channel, so for low volume
-	// streams Scan() blocks until the next keep-alive. Close the resp.Body to
-	// escape and stop the stream in a timely fashion.
-	if s.body != nil {
-		s.body.Close()
+	s.mu.Lock()
+	for _, c := range s.conns {
+		c.stop()
+		// Scanner does not have a Stop() or take a done channel, so for low
+		// volume streams Scan() blocks until the next keep-alive. Close the
+		// resp.Body to escape and stop the stream in a timely fashion.
+		if c.body != nil {
+			c.body.Close()
+		}
 	}
-	// block until the retry goroutine stops
+	s.mu.Unlock()
+	// block until the retry goroutines stop
 	s.group.Wait()
 }
 
+// newConn adds a connection to the stream. The connection is already stopped
+// if the stream has been stopped.
+func (s *Stream) newConn() *streamConn {
+	c := &streamConn{done: make(chan struct{})}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || stopped(s.done) {
+		c.stop()
+	}
+	s.conns = append(s.conns, c)
+	return c
+}
+
+// removeConn removes a stopped connection from the stream. The Messages
+// channel is closed when the last connection is removed.
+func (s *Stream) removeConn(c *streamConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, conn := range s.conns {
+		if conn == c {
+			s.conns = append(s.conns[:i], s.conns[i+1:]...)
+			break
+		}
+	}
+	if len(s.conns) <= 1 {
+		// no more overlapping connections to de-duplicate
+		s.seen = nil
+	}
+	if len(s.conns) == 0 && !s.closed {
+		s.closed = true
+		close(s.Messages)
+	}
+}
+
+// setBody records the response body being received by a connection so that
+// Stop can close it.
+func (s *Stream) setBody(c *streamConn, body io.Closer) {
+	s.mu.Lock()
+	c.body = body
+	s.mu.Unlock()
+}
+
+// swap switches the stream to receive from the given http.Request without a
+// gap. A new connection is made and, once it succeeds, both connections send
+// on Messages for the overlap duration, with duplicate Tweets dropped, before
+// the previous connections are stopped. Returns ErrStreamStopped if the
+// stream is stopped before the switchover completes.
+func (s *Stream) swap(req *http.Request, overlap time.Duration) error {
+	// the whole switchover is serialized, as connections and the Tweet IDs
+	// seen during the overlap belong to one switchover at a time
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+	s.mu.Lock()
+	if s.closed || stopped(s.done) {
+		s.mu.Unlock()
+		return ErrStreamStopped
+	}
+	s.seen = make(map[int64]struct{})
+	s.mu.Unlock()
+	// the connection is only added to the stream once connected, as Stop
+	// cannot interrupt Do and must not wait for it
+	resp, err := s.client.Do(req)
+	if err == nil && resp.StatusCode != 200 {
+		resp.Body.Close()
+		err = fmt.Errorf("twitter: stream connection failed: %s", resp.Status)
+	}
+	s.mu.Lock()
+	if err == nil && (s.closed || stopped(s.done)) {
+		resp.Body.Close()
+		err = ErrStreamStopped
+	}
+	if err != nil {
+		if len(s.conns) <= 1 {
+			s.seen = nil
+		}
+		s.mu.Unlock()
+		return err
+	}
+	// Stop stops every connection under mu before waiting for the group, so
+	// the group is only added to while the stream is running
+	c := &streamConn{done: make(chan struct{}), resp: resp}
+	s.conns = append(s.conns, c)
+	s.group.Add(1)
+	s.mu.Unlock()
+	go s.retry(c, req, newExponentialBackOff(), newAggressiveExponentialBackOff())
+
+	sleepOrDone(overlap, s.done)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stopped(s.done) {
+		return ErrStreamStopped
+	}
+	for _, old := range s.conns {
+		if old == c {
+			continue
+		}
+		old.stop()
+		if old.body != nil {
+			old.body.Close()
+		}
+	}
+	return nil
+}
+
 // retry retries making the given http.Request and receiving the response
 // according to the Twitter backoff policies. Callers should invoke in a
 // goroutine since backoffs sleep between retries.
 // https://dev.twitter.com/streaming/overview/connecting
-func (s *Stream) retry(req *http.Request, expBackOff backoff.BackOff, aggExpBackOff backoff.BackOff) {
-	// close Messages channel and decrement the wait group counter
-	defer close(s.Messages)
+func (s *Stream) retry(c *streamConn, req *http.Request, expBackOff backoff.BackOff, aggExpBackOff backoff.BackOff) {
+	// remove the connection, closing the Messages channel if it was the last,
+	// then decrement the wait group counter, so Stop returns once Messages is
+	// closed
 	defer s.group.Done()
+	defer s.removeConn(c)
+	// close a response handed over by swap if stopped before connecting
+	defer func() {
+		if c.resp != nil {
+			c.resp.Body.Close()
+		}
+	}()
 
 	var wait time.Duration
-	for !stopped(s.done) {
-		resp, err := s.client.Do(req)
+	var connected bool
+	for !stopped(c.done) {
+		resp, err := c.connect(s.client, req)
 		if err != nil {
 			// stop retrying for HTTP protocol errors
-			s.Messages <- err
+			select {
+			case s.Messages <- err:
+			case <-c.done:
+			}
 			return
 		}
 		// when err is nil, resp contains a non-nil Body which must be closed
 		defer resp.Body.Close()
-		s.body = resp.Body
+		s.setBody(c, resp.Body)
 		switch resp.StatusCode {
 		case 200:
+			if s.backfill != nil && (connected || s.backfill.resuming()) {
+				s.sendBackfill(c)
+			}
+			connected = true
 			// receive stream response Body, handles closing
-			s.receive(resp.Body)
+			s.receive(c, resp.Body)
 			expBackOff.Reset()
 			aggExpBackOff.Reset()
 		case 503:
@@ -220,14 +547,14 @@ func (s *Stream) retry(req *http.Request, expBackOff backoff.BackOff, aggExpBack
 		if wait == backoff.Stop {
 			return
 		}
-		sleepOrDone(wait, s.done)
+		sleepOrDone(wait, c.done)
 	}
 }
 
 // receive scans a stream response body, JSON decodes tokens to messages, and
 // sends messages to the Messages channel. Receiving continues until an EOF,
 // scan error, or the done channel is closed.
-func (s *Stream) receive(body io.ReadCloser) {
+func (s *Stream) receive(c *streamConn, body io.ReadCloser) {
 	defer body.Close()
 	// A bufio.Scanner steps through 'tokens' of data on each Scan() using a
 	// SplitFunc. SplitFunc tokenizes input bytes to return the number of bytes
@@ -235,23 +562,85 @@ func (s *Stream) receive(body io.ReadCloser) {
 	scanner := bufio.NewScanner(body)
 	// default ScanLines SplitFunc is incorrect for Twitter Streams, set custom
 	scanner.Split(scanLines)
-	for !stopped(s.done) && scanner.Scan() {
+	for !stopped(c.done) && scanner.Scan() {
 		token := scanner.Bytes()
+		if s.sink != nil {
+			if err := s.sink.WriteLine(token); err != nil {
+				select {
+				case s.Messages <- err:
+				case <-c.done:
+					return
+				}
+			}
+		}
 		if len(token) == 0 {
 			// empty keep-alive
 			continue
 		}
+		message := getMessage(token)
+		if s.duplicate(message) {
+			continue
+		}
+		if s.pacer != nil {
+			s.pacer.wait(message, c.done)
+		}
 		select {
 		// send messages, data, or errors
-		case s.Messages <- getMessage(token):
+		case s.Messages <- message:
 			continue
 		// allow client to Stop(), even if not receiving
-		case <-s.done:
+		case <-c.done:
 			return
 		}
 	}
 }
 
+// sendBackfill searches for Tweets missed while reconnecting and sends them
+// to the Messages channel. Search errors are sent to the Messages channel,
+// but do not stop the stream.
+func (s *Stream) sendBackfill(c *streamConn) {
+	var messages []interface{}
+	tweets, err := s.backfill.fetch()
+	if err != nil {
+		messages = append(messages, err)
+	}
+	for _, tweet := range tweets {
+		if !s.duplicate(tweet) {
+			messages = append(messages, tweet)
+		}
+	}
+	for _, message := range messages {
+		select {
+		case s.Messages <- message:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// duplicate returns true if the message is a Tweet which another connection
+// already sent while connections overlap or which was already backfilled,
+// false otherwise.
+func (s *Stream) duplicate(message interface{}) bool {
+	tweet, ok := message.(*Tweet)
+	if !ok {
+		return false
+	}
+	if s.backfill != nil && !tweet.Backfilled && s.backfill.duplicate(tweet) {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen == nil {
+		return false
+	}
+	if _, ok := s.seen[tweet.ID]; ok {
+		return true
+	}
+	s.seen[tweet.ID] = struct{}{}
+	return false
+}
+
 // getMessage unmarshals the token and returns a message struct, if the type
 // can be determined. Otherwise, returns the token unmarshalled into a data
 // map[string]interface{} or the unmarshal error.
@@ -314,6 +703,17 @@ func decodeMessage(token []byte, data map[string]interface{}) interface{} {
 		event := new(Event)
 		json.Unmarshal(token, event)
 		return event
+	} else if hasPath(data, "for_user") {
+		envelope := new(siteStreamEnvelope)
+		json.Unmarshal(token, envelope)
+		return &SiteStreamMessage{
+			ForUser: envelope.ForUser,
+			Message: getMessage(envelope.Message),
+		}
+	} else if hasPath(data, "control") {
+		notice := new(siteStreamControlNotice)
+		json.Unmarshal(token, notice)
+		return notice.Control
 	}
 	// message type unknown, return the data map[string]interface{}
 	return data
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,27 @@ func TestStream_Event(t *testing.T) {
 	assert.IsType(t, &Event{}, msg)
 }
 
+func TestStream_SiteStreamMessage(t *testing.T) {
+	msgJSON := []byte(`{"for_user": 1888, "message": {"friends": [666024290140217347, 666024290140217349]}}`)
+	msg := getMessage(msgJSON)
+	expected := &SiteStreamMessage{
+		ForUser: 1888,
+		Message: &FriendsList{Friends: []int64{666024290140217347, 666024290140217349}},
+	}
+	assert.Equal(t, expected, msg)
+
+	msgJSON = []byte(`{"for_user": 1888, "message": {"id": 20, "text": "just setting up my twttr", "retweet_count": 0}}`)
+	msg = getMessage(msgJSON)
+	assert.IsType(t, &Tweet{}, msg.(*SiteStreamMessage).Message)
+}
+
+func TestStream_SiteStreamControl(t *testing.T) {
+	msgJSON := []byte(`{"control": {"control_uri": "/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f"}}`)
+	msg := getMessage(msgJSON)
+	expected := &SiteStreamControl{ControlURI: "/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f"}
+	assert.Equal(t, expected, msg)
+}
+
 func TestStream_Unknown(t *testing.T) {
 	msgJSON := []byte(`{"unknown_data": {"new_twitter_type":"unexpected"}}`)
 	msg := getMessage(msgJSON)
@@ -127,6 +149,164 @@ func TestStream_Filter(t *testing.T) {
 	assert.Equal(t, expectedCounts, counts)
 }
 
+func TestStream_UpdateFilter(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	defer func(overlap time.Duration) {
+		filterOverlap = overlap
+	}(filterOverlap)
+	filterOverlap = 50 * time.Millisecond
+
+	// Tweet 2 matches both filters and is sent by both connections
+	overlapping := make(chan struct{})
+	mux.HandleFunc("/1.1/statuses/filter.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		switch r.URL.Query().Get("track") {
+		case "gophercon":
+			fmt.Fprintf(w, `{"id": 1, "text": "Gophercon talks!", "retweet_count": 0}`+"\r\n")
+			w.(http.Flusher).Flush()
+			<-overlapping
+			fmt.Fprintf(w, `{"id": 2, "text": "Gophercon golang talks!", "retweet_count": 0}`+"\r\n")
+		case "golang":
+			fmt.Fprintf(w,
+				`{"id": 2, "text": "Gophercon golang talks!", "retweet_count": 0}`+"\r\n"+
+					`{"id": 3, "text": "Golang talks!", "retweet_count": 0}`+"\r\n",
+			)
+			close(overlapping)
+		default:
+			http.Error(w, "Unauthorized", 401)
+			return
+		}
+		w.(http.Flusher).Flush()
+		// hold the connection open until the client closes it
+		<-r.Context().Done()
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Filter(&StreamFilterParams{Track: []string{"gophercon"}})
+	assert.NoError(t, err)
+	ids := []int64{(<-stream.Messages).(*Tweet).ID}
+
+	// failed connections leave the stream unchanged
+	err = client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"rust"}})
+	assert.EqualError(t, err, "twitter: stream connection failed: 401 Unauthorized")
+
+	updated := make(chan error)
+	go func() {
+		updated <- client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"golang"}})
+	}()
+	for ids[len(ids)-1] != 3 {
+		ids = append(ids, (<-stream.Messages).(*Tweet).ID)
+	}
+	assert.NoError(t, <-updated)
+	stream.Stop()
+	for message := range stream.Messages {
+		ids = append(ids, message.(*Tweet).ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+
+	err = client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"golang"}})
+	assert.Equal(t, ErrStreamStopped, err)
+}
+
+func TestStream_UpdateFilterStopWhileConnecting(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	connecting := make(chan struct{})
+	release := make(chan struct{})
+	disconnected := make(chan struct{})
+	mux.HandleFunc("/1.1/statuses/filter.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("track") == "golang" {
+			// hold the new connection until the stream is stopped
+			close(connecting)
+			<-release
+			defer close(disconnected)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Filter(&StreamFilterParams{Track: []string{"gophercon"}})
+	assert.NoError(t, err)
+	updated := make(chan error)
+	go func() {
+		updated <- client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"golang"}})
+	}()
+	<-connecting
+	stream.Stop()
+	// Messages is closed when Stop returns, without waiting for the connection
+	select {
+	case _, ok := <-stream.Messages:
+		assert.False(t, ok)
+	default:
+		t.Error("expected Messages to be closed when Stop returns")
+	}
+	close(release)
+	assert.Equal(t, ErrStreamStopped, <-updated)
+	// the new connection is closed rather than leaked
+	assertDone(t, disconnected, defaultTestTimeout)
+}
+
+func TestStream_UpdateFilterConcurrent(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	defer func(overlap time.Duration) {
+		filterOverlap = overlap
+	}(filterOverlap)
+	filterOverlap = 10 * time.Millisecond
+
+	mux.HandleFunc("/1.1/statuses/filter.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		fmt.Fprintf(w, `{"id": 1, "text": "Gophercon talks!", "retweet_count": 0}`+"\r\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Filter(&StreamFilterParams{Track: []string{"gophercon"}})
+	assert.NoError(t, err)
+	go func() {
+		for range stream.Messages {
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"gophercon"}}))
+		}()
+	}
+	wg.Wait()
+	stream.Stop()
+}
+
+func TestStream_UpdateFilterNotFilter(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/statuses/sample.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	client := NewClient(httpClient)
+	stream, err := client.Streams.Sample(&StreamSampleParams{})
+	assert.NoError(t, err)
+	defer stream.Stop()
+	err = client.Streams.UpdateFilter(stream, &StreamFilterParams{Track: []string{"golang"}})
+	assert.Equal(t, ErrNotFilterStream, err)
+}
+
 func TestStream_Sample(t *testing.T) {
 	httpClient, mux, server := testServer()
 	defer server.Close()
@@ -245,6 +425,61 @@ func TestStream_Site(t *testing.T) {
 	assert.Equal(t, expectedCounts, counts)
 }
 
+func TestStream_SiteAddUsers(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f/add_user.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostForm(t, map[string]string{"user_id": "623265148,113419064"}, r)
+	})
+
+	client := NewClient(httpClient)
+	_, err := client.Streams.SiteAddUsers("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f", []int64{623265148, 113419064})
+	assert.Nil(t, err)
+}
+
+func TestStream_SiteRemoveUsers(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f/remove_user.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostForm(t, map[string]string{"user_id": "623265148"}, r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(403)
+		fmt.Fprintf(w, `{"errors": [{"message": "Forbidden", "code": 200}]}`)
+	})
+
+	client := NewClient(httpClient)
+	_, err := client.Streams.SiteRemoveUsers("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f", []int64{623265148})
+	assert.EqualError(t, err, "twitter: 200 Forbidden")
+}
+
+func TestStream_SiteInfo(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f/info.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"info": {"users": [{"id": 119476949, "name": "oauth_dancer", "friends_count": 1}], "delimited": "none", "include_followings_activity": "false", "include_user_changes": "false", "replies": "none", "with": "user"}}`)
+	})
+
+	client := NewClient(httpClient)
+	info, _, err := client.Streams.SiteInfo("/1.1/site/c/1_1_54e345d655ee3e8df359ac033648530bfbe26c5f")
+	expected := &SiteStreamInfo{
+		Users:                     []SiteStreamUser{{ID: 119476949, Name: "oauth_dancer", FriendsCount: 1}},
+		Delimited:                 "none",
+		IncludeFollowingsActivity: "false",
+		IncludeUserChanges:        "false",
+		Replies:                   "none",
+		With:                      "user",
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, info)
+}
+
 func TestStream_PublicFirehose(t *testing.T) {
 	httpClient, mux, server := testServer()
 	defer server.Close()
@@ -311,7 +546,7 @@ func TestStreamRetry_ExponentialBackoff(t *testing.T) {
 	expBackoff := &BackOffRecorder{}
 	// receive messages and throw them away
 	go NewSwitchDemux().HandleChan(stream.Messages)
-	stream.retry(req, expBackoff, nil)
+	stream.retry(stream.newConn(), req, expBackoff, nil)
 	defer stream.Stop()
 	// assert exponential backoff in response to 503
 	assert.Equal(t, 1, expBackoff.Count)
@@ -345,7 +580,7 @@ func TestStreamRetry_AggressiveBackoff(t *testing.T) {
 	aggExpBackoff := &BackOffRecorder{}
 	// receive messages and throw them away
 	go NewSwitchDemux().HandleChan(stream.Messages)
-	stream.retry(req, nil, aggExpBackoff)
+	stream.retry(stream.newConn(), req, nil, aggExpBackoff)
 	defer stream.Stop()
 	// assert aggressive exponential backoff in response to 420 and 429
 	assert.Equal(t, 2, aggExpBackoff.Count)
@@ -0,0 +1,218 @@
+package text
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// conformanceCase is a case from the twitter-text conformance fixtures, which
+// expect a list of strings, a list of entities with indices, or a result.
+// Indices count UTF-16 code units, and range ends are inclusive.
+type conformanceCase struct {
+	Description string
+	Text        string
+	Expected    interface{}
+}
+
+type conformanceEntity struct {
+	ScreenName string `yaml:"screen_name"`
+	ListSlug   string `yaml:"list_slug"`
+	URL        string `yaml:"url"`
+	Hashtag    string `yaml:"hashtag"`
+	Cashtag    string `yaml:"cashtag"`
+	Indices    []int  `yaml:"indices"`
+}
+
+type conformanceResult struct {
+	WeightedLength    int  `yaml:"weightedLength"`
+	Valid             bool `yaml:"valid"`
+	Permillage        int  `yaml:"permillage"`
+	DisplayRangeStart int  `yaml:"displayRangeStart"`
+	DisplayRangeEnd   int  `yaml:"displayRangeEnd"`
+	ValidRangeStart   int  `yaml:"validRangeStart"`
+	ValidRangeEnd     int  `yaml:"validRangeEnd"`
+}
+
+// unsupportedSections are the conformance sections for features this
+// package does not have, which are skipped.
+var unsupportedSections = map[string]string{
+	"replies":                   "reply screen name extraction is not implemented",
+	"tweets":                    "version 1 Tweet validation is not implemented",
+	"usernames":                 "username validation is not implemented",
+	"lists":                     "list validation is not implemented",
+	"hashtags":                  "hashtag validation is not implemented",
+	"urls":                      "URL validation is not implemented",
+	"urls_with_unicode":         "URL validation is not implemented",
+	"WeightedTweetsCounterTest": "only the version 3 configuration is implemented",
+}
+
+func loadConformance(t *testing.T, name string) map[string][]conformanceCase {
+	data, err := ioutil.ReadFile("testdata/" + name)
+	require.Nil(t, err)
+	var fixtures struct {
+		Tests map[string][]conformanceCase
+	}
+	require.Nil(t, yaml.Unmarshal(data, &fixtures))
+	return fixtures.Tests
+}
+
+// decodeExpected re-decodes a case's expected value into v.
+func decodeExpected(t *testing.T, c conformanceCase, v interface{}) {
+	data, err := yaml.Marshal(c.Expected)
+	require.Nil(t, err)
+	require.Nil(t, yaml.Unmarshal(data, v), c.Description)
+}
+
+func TestConformance_Extract(t *testing.T) {
+	texts := map[string]func(string) []string{
+		"mentions":             entityTexts(ExtractMentions),
+		"urls":                 entityTexts(ExtractURLs),
+		"tco_urls_with_params": entityTexts(ExtractURLs),
+		"hashtags":             entityTexts(ExtractHashtags),
+		"hashtags_from_astral": entityTexts(ExtractHashtags),
+		"cashtags":             entityTexts(ExtractCashtags),
+	}
+	urls := conformanceEntities(ExtractURLs, func(e Entity) conformanceEntity {
+		return conformanceEntity{URL: e.Text}
+	})
+	indexed := map[string]func(string) []conformanceEntity{
+		"mentions_with_indices": conformanceEntities(ExtractMentions, func(e Entity) conformanceEntity {
+			return conformanceEntity{ScreenName: e.Text}
+		}),
+		"mentions_or_lists_with_indices": conformanceEntities(ExtractMentionsOrLists, func(e Entity) conformanceEntity {
+			return conformanceEntity{ScreenName: e.Text, ListSlug: e.ListSlug}
+		}),
+		"urls_with_indices":             urls,
+		"urls_with_directional_markers": urls,
+		"hashtags_with_indices": conformanceEntities(ExtractHashtags, func(e Entity) conformanceEntity {
+			return conformanceEntity{Hashtag: e.Text}
+		}),
+		"cashtags_with_indices": conformanceEntities(ExtractCashtags, func(e Entity) conformanceEntity {
+			return conformanceEntity{Cashtag: e.Text}
+		}),
+	}
+	for section, cases := range loadConformance(t, "extract.yml") {
+		t.Run(section, func(t *testing.T) {
+			extract, isTexts := texts[section]
+			extractIndexed, isIndexed := indexed[section]
+			if !isTexts && !isIndexed {
+				skipUnsupported(t, section)
+			}
+			for _, c := range cases {
+				if isTexts {
+					var expected []string
+					decodeExpected(t, c, &expected)
+					assert.Equal(t, expected, extract(c.Text), c.Description)
+				} else {
+					var expected []conformanceEntity
+					decodeExpected(t, c, &expected)
+					assert.Equal(t, expected, extractIndexed(c.Text), c.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestConformance_Validate(t *testing.T) {
+	counted := map[string]bool{
+		"WeightedTweetsWithDiscountedEmojiCounterTest": true,
+		"UnicodeDirectionalMarkerCounterTest":          true,
+	}
+	for section, cases := range loadConformance(t, "validate.yml") {
+		t.Run(section, func(t *testing.T) {
+			if !counted[section] {
+				skipUnsupported(t, section)
+			}
+			for _, c := range cases {
+				var expected conformanceResult
+				decodeExpected(t, c, &expected)
+				results := Parse(c.Text)
+				assert.Equal(t, expected, conformanceResult{
+					WeightedLength:    results.WeightedLength,
+					Valid:             results.Valid,
+					Permillage:        results.Permillage,
+					DisplayRangeStart: utf16Offset(c.Text, results.DisplayRange.Start),
+					DisplayRangeEnd:   utf16Offset(c.Text, results.DisplayRange.End) - 1,
+					ValidRangeStart:   utf16Offset(c.Text, results.ValidRange.Start),
+					ValidRangeEnd:     utf16Offset(c.Text, results.ValidRange.End) - 1,
+				}, c.Description)
+			}
+		})
+	}
+}
+
+func TestConformance_TLDs(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/tld_lib.yml")
+	if os.IsNotExist(err) {
+		t.Skip("testdata/tld_lib.yml is not vendored, run go run fetch_testdata.go")
+	}
+	require.Nil(t, err)
+	var tlds struct {
+		Country []string
+		Generic []string
+	}
+	require.Nil(t, yaml.Unmarshal(data, &tlds))
+	// tlds.go has the ASCII domains, the only ones urlPattern matches
+	assert.Equal(t, asciiSet(tlds.Country), countryTLDs, "run go generate")
+	assert.Equal(t, asciiSet(tlds.Generic), genericTLDs, "run go generate")
+}
+
+// skipUnsupported skips a section this package has no feature for, and fails
+// for unknown sections so new upstream sections are not ignored.
+func skipUnsupported(t *testing.T, section string) {
+	if reason, ok := unsupportedSections[section]; ok {
+		t.Skip(reason)
+	}
+	t.Fatalf("unknown conformance section %q", section)
+}
+
+// utf16Offset converts a code point offset into the text to a UTF-16 code
+// unit offset, as used by the conformance fixtures.
+func utf16Offset(text string, offset int) int {
+	units := 0
+	for _, r := range []rune(text)[:offset] {
+		units++
+		if r >= 0x10000 {
+			units++
+		}
+	}
+	return units
+}
+
+func asciiSet(tlds []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, tld := range tlds {
+		if tld != "" && strings.Trim(tld, "abcdefghijklmnopqrstuvwxyz") == "" {
+			set[tld] = true
+		}
+	}
+	return set
+}
+
+func entityTexts(extract func(string) []Entity) func(string) []string {
+	return func(text string) []string {
+		texts := []string{}
+		for _, entity := range extract(text) {
+			texts = append(texts, entity.Text)
+		}
+		return texts
+	}
+}
+
+func conformanceEntities(extract func(string) []Entity, convert func(Entity) conformanceEntity) func(string) []conformanceEntity {
+	return func(text string) []conformanceEntity {
+		entities := []conformanceEntity{}
+		for _, entity := range extract(text) {
+			c := convert(entity)
+			c.Indices = []int{utf16Offset(text, entity.Start), utf16Offset(text, entity.End)}
+			entities = append(entities, c)
+		}
+		return entities
+	}
+}
@@ -0,0 +1,107 @@
+package text
+
+const (
+	zeroWidthJoiner     = '\u200D'
+	variationSelector16 = '\uFE0F'
+	combiningKeycap     = '\u20E3'
+)
+
+// extractEmoji returns the emoji in the runes as a map of start to end
+// offsets. Emoji sequences, such as skin tones, flags, keycaps, and zero
+// width joined sequences, are a single emoji.
+func extractEmoji(runes []rune) map[int]int {
+	emoji := make(map[int]int)
+	for i := 0; i < len(runes); {
+		end := emojiEnd(runes, i)
+		if end > i {
+			emoji[i] = end
+			i = end
+			continue
+		}
+		i++
+	}
+	return emoji
+}
+
+// emojiEnd returns the end of the emoji starting at offset i, or i if there
+// is no emoji at i.
+func emojiEnd(runes []rune, i int) int {
+	r := runes[i]
+	switch {
+	case isRegionalIndicator(r):
+		// flags are pairs of regional indicators
+		if i+1 < len(runes) && isRegionalIndicator(runes[i+1]) {
+			return i + 2
+		}
+		return i + 1
+	case isKeycapBase(r):
+		// keycaps are a digit, '#', or '*', optionally FE0F, and U+20E3
+		j := i + 1
+		if j < len(runes) && runes[j] == variationSelector16 {
+			j++
+		}
+		if j < len(runes) && runes[j] == combiningKeycap {
+			return j + 1
+		}
+		return i
+	}
+	end := i
+	for {
+		if !isEmojiBase(runes, end) {
+			return end
+		}
+		end++
+		if end < len(runes) && runes[end] == variationSelector16 {
+			end++
+		}
+		if end < len(runes) && isSkinToneModifier(runes[end]) {
+			end++
+		}
+		for end < len(runes) && isTag(runes[end]) {
+			end++
+		}
+		// zero width joiners combine emoji into a single emoji
+		if end+1 < len(runes) && runes[end] == zeroWidthJoiner && isEmojiBase(runes, end+1) {
+			end++
+			continue
+		}
+		return end
+	}
+}
+
+// isEmojiBase returns true if the rune at offset i begins an emoji.
+// Characters which default to text presentation, like the copyright sign,
+// are only emoji when followed by variation selector 16.
+func isEmojiBase(runes []rune, i int) bool {
+	if i >= len(runes) {
+		return false
+	}
+	switch r := runes[i]; {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF, r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r >= 0x2194 && r <= 0x21AA, r == 0x24C2, r >= 0x25AA && r <= 0x25FE, r == 0x2934, r == 0x2935:
+		return i+1 < len(runes) && runes[i+1] == variationSelector16
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isKeycapBase(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '#' || r == '*'
+}
+
+func isSkinToneModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
@@ -0,0 +1,331 @@
+package text
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EntityType is the type of an Entity.
+type EntityType string
+
+// Entity types.
+const (
+	URLEntity     EntityType = "url"
+	MentionEntity EntityType = "mention"
+	HashtagEntity EntityType = "hashtag"
+	CashtagEntity EntityType = "cashtag"
+)
+
+// Entity is a URL, mention, hashtag, or cashtag extracted from text.
+type Entity struct {
+	Type EntityType
+	// Text is the URL, or the screen name, hashtag, or cashtag symbol
+	// without its leading "@", "#", or "$".
+	Text string
+	// ListSlug is the list of a list mention (e.g. "/golang" for
+	// "@twitter/golang"), including the leading "/".
+	ListSlug string
+	// Start and End are the code point offsets of the entity in the text,
+	// including any leading "@", "#", or "$".
+	Start int
+	End   int
+}
+
+var (
+	urlPattern     = regexp.MustCompile(`(?i)(https?://)?((?:[\p{L}\p{N}](?:[\p{L}\p{N}_-]*[\p{L}\p{N}])?\.)+([a-z]{2,63}))(:\d{1,5})?([/?#][^\s<>"]*)?`)
+	mentionPattern = regexp.MustCompile(`[@＠]([a-zA-Z0-9_]{1,20})(/[a-zA-Z][a-zA-Z0-9_-]{0,24})?`)
+	hashtagPattern = regexp.MustCompile(`[#＃]([\p{L}\p{M}\p{Nd}_\x{200c}\x{200d}\x{00b7}\x{30fb}]*[\p{L}\p{M}][\p{L}\p{M}\p{Nd}_\x{200c}\x{200d}\x{00b7}\x{30fb}]*)`)
+	cashtagPattern = regexp.MustCompile(`\$([a-zA-Z]{1,6})([._][a-zA-Z]{1,2})?`)
+)
+
+// ExtractEntities returns the URLs, mentions (including lists), hashtags, and
+// cashtags in the text, ordered by Start. Entities which overlap an earlier
+// entity, such as a hashtag within a URL, are removed.
+func ExtractEntities(text string) []Entity {
+	var entities []Entity
+	entities = append(entities, ExtractURLs(text)...)
+	entities = append(entities, extractMentions(text)...)
+	entities = append(entities, extractHashtags(text)...)
+	entities = append(entities, extractCashtags(text)...)
+	return removeOverlaps(entities)
+}
+
+// ExtractURLs returns the URLs in the text. URLs must end in a known top
+// level domain, and without a protocol single label domains with a country
+// code top level domain (e.g. "example.jp") must have a path.
+func ExtractURLs(text string) []Entity {
+	offsets := newOffsets(text)
+	var urls []Entity
+	for _, m := range urlPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		protocol := m[3] > m[2]
+		if r, ok := runeBefore(text, start); ok {
+			if strings.ContainsRune("@＠$#＃", r) || isASCIIAlnum(r) {
+				continue
+			}
+			if !protocol && strings.ContainsRune("-_./", r) {
+				continue
+			}
+		}
+		domain, tld := text[m[4]:m[5]], strings.ToLower(text[m[6]:m[7]])
+		hasPath := m[10] >= 0
+		country := countryTLDs[tld]
+		if !genericTLDs[tld] && !country {
+			continue
+		}
+		if !protocol && country && !hasPath && strings.Count(domain, ".") == 1 {
+			continue
+		}
+		if hasPath {
+			end = m[10] + len(trimPath(text[m[10]:m[11]]))
+		}
+		urls = append(urls, Entity{
+			Type:  URLEntity,
+			Text:  text[start:end],
+			Start: offsets.of(start),
+			End:   offsets.of(end),
+		})
+	}
+	return urls
+}
+
+// ExtractMentions returns the mentions of users in the text, but not of
+// lists.
+func ExtractMentions(text string) []Entity {
+	var mentions []Entity
+	for _, mention := range extractMentions(text) {
+		if mention.ListSlug == "" {
+			mentions = append(mentions, mention)
+		}
+	}
+	return mentions
+}
+
+// ExtractMentionsOrLists returns the mentions of users and lists in the text.
+func ExtractMentionsOrLists(text string) []Entity {
+	return extractMentions(text)
+}
+
+// ExtractHashtags returns the hashtags in the text, except those within URLs.
+func ExtractHashtags(text string) []Entity {
+	return withoutURLs(text, extractHashtags(text))
+}
+
+// ExtractCashtags returns the cashtags in the text, except those within URLs.
+func ExtractCashtags(text string) []Entity {
+	return withoutURLs(text, extractCashtags(text))
+}
+
+// extractMentions returns the mentions of users and lists in the text,
+// except those within URLs.
+func extractMentions(text string) []Entity {
+	offsets := newOffsets(text)
+	var mentions []Entity
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		if r, ok := runeBefore(text, start); ok && isInvalidMentionPrefix(r) && !afterRetweet(text[:start]) {
+			continue
+		}
+		if r, ok := runeAt(text, end); ok && (r == '@' || r == '＠' || isLatinAccent(r)) {
+			continue
+		}
+		if strings.HasPrefix(text[end:], "://") {
+			continue
+		}
+		mention := Entity{
+			Type:  MentionEntity,
+			Text:  text[m[2]:m[3]],
+			Start: offsets.of(start),
+			End:   offsets.of(end),
+		}
+		if m[4] >= 0 {
+			mention.ListSlug = text[m[4]:m[5]]
+		}
+		mentions = append(mentions, mention)
+	}
+	return withoutURLs(text, mentions)
+}
+
+// extractHashtags returns the hashtags in the text.
+func extractHashtags(text string) []Entity {
+	offsets := newOffsets(text)
+	var hashtags []Entity
+	for _, m := range hashtagPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		if r, ok := runeBefore(text, start); ok && r != '\uFE0E' && r != '\uFE0F' &&
+			(r == '&' || r == '_' || unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		if r, ok := runeAt(text, end); ok && (r == '#' || r == '＃') {
+			continue
+		}
+		if strings.HasPrefix(text[end:], "://") {
+			continue
+		}
+		hashtags = append(hashtags, Entity{
+			Type:  HashtagEntity,
+			Text:  text[m[2]:m[3]],
+			Start: offsets.of(start),
+			End:   offsets.of(end),
+		})
+	}
+	return hashtags
+}
+
+// extractCashtags returns the cashtags in the text.
+func extractCashtags(text string) []Entity {
+	offsets := newOffsets(text)
+	var cashtags []Entity
+	for _, m := range cashtagPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		if r, ok := runeBefore(text, start); ok && !unicode.IsSpace(r) {
+			continue
+		}
+		if !isCashtagEnd(text, end) {
+			// without the suffix, e.g. "$ab" in "$ab.cde"
+			if end = m[3]; m[4] < 0 || !isCashtagEnd(text, end) {
+				continue
+			}
+		}
+		cashtags = append(cashtags, Entity{
+			Type:  CashtagEntity,
+			Text:  text[m[2]:end],
+			Start: offsets.of(start),
+			End:   offsets.of(end),
+		})
+	}
+	return cashtags
+}
+
+// isCashtagEnd returns true if a cashtag may end at byte offset i.
+func isCashtagEnd(text string, i int) bool {
+	r, ok := runeAt(text, i)
+	return !ok || unicode.IsSpace(r) || (r < utf8.RuneSelf && unicode.IsPunct(r)) || (r < utf8.RuneSelf && unicode.IsSymbol(r))
+}
+
+// trimPath removes trailing punctuation from a URL path, keeping balanced
+// closing parentheses.
+func trimPath(path string) string {
+	for len(path) > 1 {
+		r, size := utf8.DecodeLastRuneInString(path)
+		if r == ')' && strings.Count(path, "(") >= strings.Count(path, ")") {
+			break
+		}
+		if !strings.ContainsRune(`.,:;!?'")]}»”’…`, r) {
+			break
+		}
+		path = path[:len(path)-size]
+	}
+	return path
+}
+
+// withoutURLs removes entities which overlap URLs in the text.
+func withoutURLs(text string, entities []Entity) []Entity {
+	urls := ExtractURLs(text)
+	if len(urls) == 0 {
+		return entities
+	}
+	var kept []Entity
+	for _, entity := range entities {
+		overlaps := false
+		for _, url := range urls {
+			if entity.Start < url.End && url.Start < entity.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, entity)
+		}
+	}
+	return kept
+}
+
+// removeOverlaps sorts entities by Start and removes those which overlap an
+// earlier entity.
+func removeOverlaps(entities []Entity) []Entity {
+	sort.Stable(entitiesByStart(entities))
+	var kept []Entity
+	for _, entity := range entities {
+		if len(kept) > 0 && entity.Start < kept[len(kept)-1].End {
+			continue
+		}
+		kept = append(kept, entity)
+	}
+	return kept
+}
+
+// entitiesByStart sorts Entities by Start.
+type entitiesByStart []Entity
+
+func (e entitiesByStart) Len() int           { return len(e) }
+func (e entitiesByStart) Less(i, j int) bool { return e[i].Start < e[j].Start }
+func (e entitiesByStart) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// offsets converts byte offsets in a string to code point offsets.
+type offsets []int
+
+func newOffsets(text string) offsets {
+	o := make(offsets, len(text)+1)
+	count := 0
+	for i := range text {
+		o[i] = count
+		count++
+	}
+	o[len(text)] = count
+	return o
+}
+
+// of returns the code point offset of a byte offset at a rune boundary.
+func (o offsets) of(i int) int {
+	return o[i]
+}
+
+// runeBefore returns the rune ending at byte offset i, if any.
+func runeBefore(text string, i int) (rune, bool) {
+	if i == 0 {
+		return 0, false
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return r, true
+}
+
+// runeAt returns the rune starting at byte offset i, if any.
+func runeAt(text string, i int) (rune, bool) {
+	if i >= len(text) {
+		return 0, false
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return r, true
+}
+
+func isASCIIAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// isInvalidMentionPrefix returns true for characters which may not precede
+// a mention.
+func isInvalidMentionPrefix(r rune) bool {
+	return isASCIIAlnum(r) || strings.ContainsRune("_!#$%&*@＠", r)
+}
+
+// afterRetweet returns true if the text ends in "RT" or "RT:", which may
+// directly precede a mention.
+func afterRetweet(text string) bool {
+	text = strings.TrimSuffix(text, ":")
+	if len(text) < 2 || !strings.EqualFold(text[len(text)-2:], "rt") {
+		return false
+	}
+	r, ok := runeBefore(text, len(text)-2)
+	return !ok || !(isASCIIAlnum(r) || strings.ContainsRune("_+~.-", r))
+}
+
+// isLatinAccent returns true for accented Latin characters, which may not
+// directly follow a mention.
+func isLatinAccent(r rune) bool {
+	return (r >= 0x00C0 && r <= 0x00D6) || (r >= 0x00D8 && r <= 0x00F6) || (r >= 0x00F8 && r <= 0x024F) ||
+		(r >= 0x0300 && r <= 0x036F) || (r >= 0x1E00 && r <= 0x1EFF)
+}
@@ -0,0 +1,49 @@
+//go:build ignore
+// +build ignore
+
+// fetch_testdata downloads twitter-text's conformance fixtures and
+// tld_lib.yml into testdata, unmodified.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"path/filepath"
+)
+
+const conformanceURL = "https://raw.githubusercontent.com/twitter/twitter-text/%s/conformance/%s"
+
+var (
+	ref = flag.String("ref", "master", "twitter-text branch, tag, or commit")
+	dir = flag.String("d", "testdata", "output directory")
+)
+
+var files = []string{"extract.yml", "validate.yml", "tld_lib.yml"}
+
+func main() {
+	flag.Parse()
+	for _, name := range files {
+		data, err := fetch(fmt.Sprintf(conformanceURL, *ref, name))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(*dir, name), data, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
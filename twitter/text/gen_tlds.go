@@ -0,0 +1,91 @@
+//go:build ignore
+// +build ignore
+
+// gen_tlds generates tlds.go from the top level domains listed in
+// twitter-text's tld_lib.yml, which fetch_testdata.go downloads.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	src = flag.String("src", "testdata/tld_lib.yml", "path of tld_lib.yml")
+	out = flag.String("o", "tlds.go", "output file")
+)
+
+func main() {
+	flag.Parse()
+	data, err := ioutil.ReadFile(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var tlds struct {
+		Country []string
+		Generic []string
+	}
+	if err := yaml.Unmarshal(data, &tlds); err != nil {
+		log.Fatal(err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_tlds.go; DO NOT EDIT.\n\n")
+	buf.WriteString("//go:generate go run fetch_testdata.go\n")
+	buf.WriteString("//go:generate go run gen_tlds.go\n\n")
+	buf.WriteString("package text\n\n")
+	writeTLDs(&buf, "countryTLDs", "country code top level domains", tlds.Country)
+	buf.WriteString("\n")
+	writeTLDs(&buf, "genericTLDs", "generic top level domains", tlds.Generic)
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeTLDs writes a map of the lower case ASCII top level domains, the only
+// ones matched by urlPattern.
+func writeTLDs(buf *bytes.Buffer, name, doc string, tlds []string) {
+	var ascii []string
+	for _, tld := range tlds {
+		if isLowerASCII(tld) {
+			ascii = append(ascii, tld)
+		}
+	}
+	sort.Strings(ascii)
+	fmt.Fprintf(buf, "// %s are the %s recognized in URLs.\n", name, doc)
+	fmt.Fprintf(buf, "var %s = map[string]bool{\n", name)
+	line := 0
+	for _, tld := range ascii {
+		entry := fmt.Sprintf("%q: true,", tld)
+		if line > 0 && line+1+len(entry) > 72 {
+			buf.WriteString("\n")
+			line = 0
+		}
+		if line > 0 {
+			buf.WriteString(" ")
+			line++
+		}
+		buf.WriteString(entry)
+		line += len(entry)
+	}
+	buf.WriteString("\n}\n")
+}
+
+func isLowerASCII(s string) bool {
+	for _, c := range s {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,183 @@
+// Package text counts, validates, and extracts entities from Tweet text in
+// the same way as version 3 of the twitter-text libraries, so that text can
+// be checked before it is posted.
+// https://github.com/twitter/twitter-text
+//
+// Indices and ranges count Unicode code points, like the entity Indices
+// returned by the Twitter API, rather than the UTF-16 code units used by the
+// JavaScript and Java twitter-text libraries.
+package text
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// WeightRange weights the code points from Start to End, inclusive.
+type WeightRange struct {
+	Start  rune
+	End    rune
+	Weight int
+}
+
+// Config configures how Tweet text is weighted and how long it may be.
+type Config struct {
+	Version int
+	// MaxWeightedTweetLength is the maximum weighted length of valid text.
+	MaxWeightedTweetLength int
+	// Scale divides weights to give the weighted length.
+	Scale int
+	// DefaultWeight is the weight of code points outside the Ranges and of
+	// emoji.
+	DefaultWeight int
+	// TransformedURLLength is the length of a URL once shortened by t.co.
+	TransformedURLLength int
+	// Ranges weight code points, such as Latin characters, differently from
+	// the DefaultWeight.
+	Ranges []WeightRange
+	// EmojiParsingEnabled weights each emoji, including sequences of code
+	// points, as a single DefaultWeight character.
+	EmojiParsingEnabled bool
+}
+
+// V3Config is the twitter-text version 3 configuration. Latin characters,
+// punctuation, and spaces count 1, other characters and emoji count 2, and
+// URLs count 23 towards a maximum of 280.
+var V3Config = Config{
+	Version:                3,
+	MaxWeightedTweetLength: 280,
+	Scale:                  100,
+	DefaultWeight:          200,
+	TransformedURLLength:   23,
+	Ranges: []WeightRange{
+		{Start: 0, End: 4351, Weight: 100},
+		{Start: 8192, End: 8205, Weight: 100},
+		{Start: 8208, End: 8223, Weight: 100},
+		{Start: 8242, End: 8247, Weight: 100},
+	},
+	EmojiParsingEnabled: true,
+}
+
+// Range is a range of code point offsets, from Start inclusive to End
+// exclusive.
+type Range struct {
+	Start int
+	End   int
+}
+
+// ParseResults describe the weighted length and validity of Tweet text.
+type ParseResults struct {
+	// WeightedLength is the length of the text as counted by Twitter.
+	WeightedLength int
+	// Permillage is the WeightedLength in thousandths of the maximum.
+	Permillage int
+	// Valid is true if the text is not empty, is within the maximum
+	// weighted length, and has no invalid characters.
+	Valid bool
+	// DisplayRange is the range of the text.
+	DisplayRange Range
+	// ValidRange is the range of the text within the maximum weighted length.
+	ValidRange Range
+}
+
+// Parse returns the ParseResults of the text using the V3Config.
+func Parse(text string) ParseResults {
+	return V3Config.Parse(text)
+}
+
+// WeightedLength returns the weighted length of the text using the V3Config.
+func WeightedLength(text string) int {
+	return V3Config.Parse(text).WeightedLength
+}
+
+// IsValid returns true if the text is valid using the V3Config.
+func IsValid(text string) bool {
+	return V3Config.Parse(text).Valid
+}
+
+// Parse returns the ParseResults of the text. The text is NFC normalized
+// before it is weighted, but ranges are offsets into the given text.
+func (c Config) Parse(text string) ParseResults {
+	normalized, origin := normalize(text)
+	runes := []rune(normalized)
+
+	urls := make(map[int]int)
+	for _, url := range ExtractURLs(normalized) {
+		urls[url.Start] = url.End
+	}
+	var emoji map[int]int
+	if c.EmojiParsingEnabled {
+		emoji = extractEmoji(runes)
+	}
+
+	max := c.MaxWeightedTweetLength * c.Scale
+	weighted, validEnd := 0, 0
+	hasInvalid := false
+	for i := 0; i < len(runes); {
+		end := i + 1
+		if urlEnd, ok := urls[i]; ok {
+			weighted += c.TransformedURLLength * c.Scale
+			end = urlEnd
+		} else if emojiEnd, ok := emoji[i]; ok {
+			weighted += c.DefaultWeight
+			end = emojiEnd
+		} else {
+			weighted += c.weight(runes[i])
+			if isInvalidChar(runes[i]) {
+				hasInvalid = true
+			}
+		}
+		if weighted <= max {
+			validEnd = end
+		}
+		i = end
+	}
+
+	length := weighted / c.Scale
+	results := ParseResults{
+		WeightedLength: length,
+		Permillage:     length * 1000 / c.MaxWeightedTweetLength,
+		Valid:          len(runes) > 0 && !hasInvalid && weighted <= max,
+		DisplayRange:   Range{Start: 0, End: origin[len(runes)]},
+		ValidRange:     Range{Start: 0, End: origin[validEnd]},
+	}
+	return results
+}
+
+// normalize returns the NFC normalized text and a slice mapping each offset
+// into the normalized text to the corresponding offset into the text.
+// Offsets within a normalized segment map to the start of the segment.
+func normalize(text string) (string, []int) {
+	var it norm.Iter
+	it.InitString(norm.NFC, text)
+	var normalized []byte
+	origin := []int{0}
+	pos, count := 0, 0
+	for !it.Done() {
+		segment := it.Next()
+		normalized = append(normalized, segment...)
+		for i := 1; i < utf8.RuneCount(segment); i++ {
+			origin = append(origin, count)
+		}
+		count += utf8.RuneCountInString(text[pos:it.Pos()])
+		pos = it.Pos()
+		origin = append(origin, count)
+	}
+	return string(normalized), origin
+}
+
+// weight returns the weight of a code point.
+func (c Config) weight(r rune) int {
+	for _, weightRange := range c.Ranges {
+		if r >= weightRange.Start && r <= weightRange.End {
+			return weightRange.Weight
+		}
+	}
+	return c.DefaultWeight
+}
+
+// isInvalidChar returns true for code points which may not be posted.
+func isInvalidChar(r rune) bool {
+	return r == '\uFFFE' || r == '\uFEFF' || r == '\uFFFF'
+}
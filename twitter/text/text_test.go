@@ -0,0 +1,66 @@
+package text
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedLength(t *testing.T) {
+	assert.Equal(t, 0, WeightedLength(""))
+	assert.Equal(t, 5, WeightedLength("hello"))
+	assert.Equal(t, 4, WeightedLength("日本"))
+	assert.Equal(t, 27, WeightedLength("see https://golang.org/doc/"))
+}
+
+func TestIsValid(t *testing.T) {
+	assert.False(t, IsValid(""))
+	assert.True(t, IsValid("hello"))
+	assert.True(t, IsValid(strings.Repeat("a", 280)))
+	assert.False(t, IsValid(strings.Repeat("a", 281)))
+	assert.False(t, IsValid("a\uFEFFb"))
+}
+
+func TestConfig_Parse(t *testing.T) {
+	config := V3Config
+	config.MaxWeightedTweetLength = 10
+	config.EmojiParsingEnabled = false
+	// without emoji parsing, each code point of an emoji is weighted
+	results := config.Parse("hi 👍🏽")
+	assert.Equal(t, ParseResults{
+		WeightedLength: 7,
+		Permillage:     700,
+		Valid:          true,
+		DisplayRange:   Range{0, 5},
+		ValidRange:     Range{0, 5},
+	}, results)
+}
+
+func TestExtractEntities(t *testing.T) {
+	text := "RT @golang: #go 1.8 http://golang.org/#go $GOOG @gophers/team"
+	expected := []Entity{
+		{Type: MentionEntity, Text: "golang", Start: 3, End: 10},
+		{Type: HashtagEntity, Text: "go", Start: 12, End: 15},
+		{Type: URLEntity, Text: "http://golang.org/#go", Start: 20, End: 41},
+		{Type: CashtagEntity, Text: "GOOG", Start: 42, End: 47},
+		{Type: MentionEntity, Text: "gophers", ListSlug: "/team", Start: 48, End: 61},
+	}
+	assert.Equal(t, expected, ExtractEntities(text))
+}
+
+func TestExtractURLs_TLDs(t *testing.T) {
+	urls := func(text string) []string {
+		var texts []string
+		for _, url := range ExtractURLs(text) {
+			texts = append(texts, url.Text)
+		}
+		return texts
+	}
+	assert.Equal(t, []string{"gopher.photography", "go.dev", "a.b.jp"}, urls("gopher.photography go.dev a.b.jp"))
+	// two letters which are not a country code are not a top level domain
+	assert.Nil(t, urls("a.b.c.zz"))
+	// even with a protocol
+	assert.Nil(t, urls("http://a.b.c.zz http://foo.zz/path"))
+	assert.Equal(t, []string{"http://example.jp"}, urls("http://example.jp"))
+}
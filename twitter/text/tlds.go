@@ -0,0 +1,318 @@
+// Code generated by gen_tlds.go; DO NOT EDIT.
+
+//go:generate go run fetch_testdata.go
+//go:generate go run gen_tlds.go
+
+package text
+
+// countryTLDs are the country code top level domains recognized in URLs.
+var countryTLDs = map[string]bool{
+	"ac": true, "ad": true, "ae": true, "af": true, "ag": true, "ai": true,
+	"al": true, "am": true, "ao": true, "aq": true, "ar": true, "as": true,
+	"at": true, "au": true, "aw": true, "ax": true, "az": true, "ba": true,
+	"bb": true, "bd": true, "be": true, "bf": true, "bg": true, "bh": true,
+	"bi": true, "bj": true, "bm": true, "bn": true, "bo": true, "br": true,
+	"bs": true, "bt": true, "bv": true, "bw": true, "by": true, "bz": true,
+	"ca": true, "cc": true, "cd": true, "cf": true, "cg": true, "ch": true,
+	"ci": true, "ck": true, "cl": true, "cm": true, "cn": true, "co": true,
+	"cr": true, "cu": true, "cv": true, "cw": true, "cx": true, "cy": true,
+	"cz": true, "de": true, "dj": true, "dk": true, "dm": true, "do": true,
+	"dz": true, "ec": true, "ee": true, "eg": true, "er": true, "es": true,
+	"et": true, "eu": true, "fi": true, "fj": true, "fk": true, "fm": true,
+	"fo": true, "fr": true, "ga": true, "gb": true, "gd": true, "ge": true,
+	"gf": true, "gg": true, "gh": true, "gi": true, "gl": true, "gm": true,
+	"gn": true, "gp": true, "gq": true, "gr": true, "gs": true, "gt": true,
+	"gu": true, "gw": true, "gy": true, "hk": true, "hm": true, "hn": true,
+	"hr": true, "ht": true, "hu": true, "id": true, "ie": true, "il": true,
+	"im": true, "in": true, "io": true, "iq": true, "ir": true, "is": true,
+	"it": true, "je": true, "jm": true, "jo": true, "jp": true, "ke": true,
+	"kg": true, "kh": true, "ki": true, "km": true, "kn": true, "kp": true,
+	"kr": true, "kw": true, "ky": true, "kz": true, "la": true, "lb": true,
+	"lc": true, "li": true, "lk": true, "lr": true, "ls": true, "lt": true,
+	"lu": true, "lv": true, "ly": true, "ma": true, "mc": true, "md": true,
+	"me": true, "mg": true, "mh": true, "mk": true, "ml": true, "mm": true,
+	"mn": true, "mo": true, "mp": true, "mq": true, "mr": true, "ms": true,
+	"mt": true, "mu": true, "mv": true, "mw": true, "mx": true, "my": true,
+	"mz": true, "na": true, "nc": true, "ne": true, "nf": true, "ng": true,
+	"ni": true, "nl": true, "no": true, "np": true, "nr": true, "nu": true,
+	"nz": true, "om": true, "pa": true, "pe": true, "pf": true, "pg": true,
+	"ph": true, "pk": true, "pl": true, "pm": true, "pn": true, "pr": true,
+	"ps": true, "pt": true, "pw": true, "py": true, "qa": true, "re": true,
+	"ro": true, "rs": true, "ru": true, "rw": true, "sa": true, "sb": true,
+	"sc": true, "sd": true, "se": true, "sg": true, "sh": true, "si": true,
+	"sj": true, "sk": true, "sl": true, "sm": true, "sn": true, "so": true,
+	"sr": true, "ss": true, "st": true, "su": true, "sv": true, "sx": true,
+	"sy": true, "sz": true, "tc": true, "td": true, "tf": true, "tg": true,
+	"th": true, "tj": true, "tk": true, "tl": true, "tm": true, "tn": true,
+	"to": true, "tr": true, "tt": true, "tv": true, "tw": true, "tz": true,
+	"ua": true, "ug": true, "uk": true, "us": true, "uy": true, "uz": true,
+	"va": true, "vc": true, "ve": true, "vg": true, "vi": true, "vn": true,
+	"vu": true, "wf": true, "ws": true, "ye": true, "yt": true, "za": true,
+	"zm": true, "zw": true,
+}
+
+// genericTLDs are the generic top level domains recognized in URLs.
+var genericTLDs = map[string]bool{
+	"aaa": true, "aarp": true, "abarth": true, "abb": true, "abbott": true,
+	"abbvie": true, "abc": true, "able": true, "abogado": true,
+	"abudhabi": true, "academy": true, "accenture": true,
+	"accountant": true, "accountants": true, "aco": true, "actor": true,
+	"ads": true, "adult": true, "aeg": true, "aero": true, "aetna": true,
+	"afl": true, "africa": true, "agakhan": true, "agency": true,
+	"aig": true, "airbus": true, "airforce": true, "airtel": true,
+	"akdn": true, "alfaromeo": true, "alibaba": true, "alipay": true,
+	"allfinanz": true, "allstate": true, "ally": true, "alsace": true,
+	"alstom": true, "amazon": true, "americanexpress": true,
+	"americanfamily": true, "amex": true, "amfam": true, "amica": true,
+	"amsterdam": true, "analytics": true, "android": true, "anquan": true,
+	"anz": true, "aol": true, "apartments": true, "app": true,
+	"apple": true, "aquarelle": true, "arab": true, "aramco": true,
+	"archi": true, "army": true, "arpa": true, "art": true, "arte": true,
+	"asda": true, "asia": true, "associates": true, "athleta": true,
+	"attorney": true, "auction": true, "audi": true, "audible": true,
+	"audio": true, "auspost": true, "author": true, "auto": true,
+	"autos": true, "avianca": true, "aws": true, "axa": true, "azure": true,
+	"baby": true, "baidu": true, "banamex": true, "bananarepublic": true,
+	"band": true, "bank": true, "bar": true, "barcelona": true,
+	"barclaycard": true, "barclays": true, "barefoot": true,
+	"bargains": true, "baseball": true, "basketball": true, "bauhaus": true,
+	"bayern": true, "bbc": true, "bbt": true, "bbva": true, "bcg": true,
+	"bcn": true, "beats": true, "beauty": true, "beer": true,
+	"bentley": true, "berlin": true, "best": true, "bestbuy": true,
+	"bet": true, "bharti": true, "bible": true, "bid": true, "bike": true,
+	"bing": true, "bingo": true, "bio": true, "biz": true, "black": true,
+	"blackfriday": true, "blockbuster": true, "blog": true,
+	"bloomberg": true, "blue": true, "bms": true, "bmw": true,
+	"bnpparibas": true, "boats": true, "boehringer": true, "bofa": true,
+	"bom": true, "bond": true, "boo": true, "book": true, "booking": true,
+	"bosch": true, "bostik": true, "boston": true, "bot": true,
+	"boutique": true, "box": true, "bradesco": true, "bridgestone": true,
+	"broadway": true, "broker": true, "brother": true, "brussels": true,
+	"build": true, "builders": true, "business": true, "buy": true,
+	"buzz": true, "bzh": true, "cab": true, "cafe": true, "cal": true,
+	"call": true, "calvinklein": true, "cam": true, "camera": true,
+	"camp": true, "canon": true, "capetown": true, "capital": true,
+	"capitalone": true, "car": true, "caravan": true, "cards": true,
+	"care": true, "career": true, "careers": true, "cars": true,
+	"casa": true, "case": true, "cash": true, "casino": true, "cat": true,
+	"catering": true, "catholic": true, "cba": true, "cbn": true,
+	"cbre": true, "cbs": true, "center": true, "ceo": true, "cern": true,
+	"cfa": true, "cfd": true, "chanel": true, "channel": true,
+	"charity": true, "chase": true, "chat": true, "cheap": true,
+	"chintai": true, "christmas": true, "chrome": true, "church": true,
+	"cipriani": true, "circle": true, "cisco": true, "citadel": true,
+	"citi": true, "citic": true, "city": true, "cityeats": true,
+	"claims": true, "cleaning": true, "click": true, "clinic": true,
+	"clinique": true, "clothing": true, "cloud": true, "club": true,
+	"clubmed": true, "coach": true, "codes": true, "coffee": true,
+	"college": true, "cologne": true, "com": true, "comcast": true,
+	"commbank": true, "community": true, "company": true, "compare": true,
+	"computer": true, "comsec": true, "condos": true, "construction": true,
+	"consulting": true, "contact": true, "contractors": true,
+	"cooking": true, "cookingchannel": true, "cool": true, "coop": true,
+	"corsica": true, "country": true, "coupon": true, "coupons": true,
+	"courses": true, "cpa": true, "credit": true, "creditcard": true,
+	"creditunion": true, "cricket": true, "crown": true, "crs": true,
+	"cruise": true, "cruises": true, "cuisinella": true, "cymru": true,
+	"cyou": true, "dabur": true, "dad": true, "dance": true, "data": true,
+	"date": true, "dating": true, "datsun": true, "day": true, "dclk": true,
+	"dds": true, "deal": true, "dealer": true, "deals": true,
+	"degree": true, "delivery": true, "dell": true, "deloitte": true,
+	"delta": true, "democrat": true, "dental": true, "dentist": true,
+	"desi": true, "design": true, "dev": true, "dhl": true,
+	"diamonds": true, "diet": true, "digital": true, "direct": true,
+	"directory": true, "discount": true, "discover": true, "dish": true,
+	"diy": true, "dnp": true, "docs": true, "doctor": true, "dog": true,
+	"domains": true, "dot": true, "download": true, "drive": true,
+	"dtv": true, "dubai": true, "dunlop": true, "dupont": true,
+	"durban": true, "dvag": true, "dvr": true, "earth": true, "eat": true,
+	"eco": true, "edeka": true, "edu": true, "education": true,
+	"email": true, "emerck": true, "energy": true, "engineer": true,
+	"engineering": true, "enterprises": true, "epson": true,
+	"equipment": true, "ericsson": true, "erni": true, "esq": true,
+	"estate": true, "etisalat": true, "eurovision": true, "eus": true,
+	"events": true, "exchange": true, "expert": true, "exposed": true,
+	"express": true, "extraspace": true, "fage": true, "fail": true,
+	"fairwinds": true, "faith": true, "family": true, "fan": true,
+	"fans": true, "farm": true, "farmers": true, "fashion": true,
+	"fast": true, "fedex": true, "feedback": true, "ferrari": true,
+	"ferrero": true, "fiat": true, "fidelity": true, "fido": true,
+	"film": true, "final": true, "finance": true, "financial": true,
+	"fire": true, "firestone": true, "firmdale": true, "fish": true,
+	"fishing": true, "fit": true, "fitness": true, "flickr": true,
+	"flights": true, "flir": true, "florist": true, "flowers": true,
+	"fly": true, "foo": true, "food": true, "foodnetwork": true,
+	"football": true, "ford": true, "forex": true, "forsale": true,
+	"forum": true, "foundation": true, "fox": true, "free": true,
+	"fresenius": true, "frl": true, "frogans": true, "frontdoor": true,
+	"frontier": true, "ftr": true, "fujitsu": true, "fun": true,
+	"fund": true, "furniture": true, "futbol": true, "fyi": true,
+	"gal": true, "gallery": true, "gallo": true, "gallup": true,
+	"game": true, "games": true, "gap": true, "garden": true, "gay": true,
+	"gbiz": true, "gdn": true, "gea": true, "gent": true, "genting": true,
+	"george": true, "ggee": true, "gift": true, "gifts": true,
+	"gives": true, "giving": true, "glass": true, "gle": true,
+	"global": true, "globo": true, "gmail": true, "gmbh": true, "gmo": true,
+	"gmx": true, "godaddy": true, "gold": true, "goldpoint": true,
+	"golf": true, "goo": true, "goodyear": true, "goog": true,
+	"google": true, "gop": true, "got": true, "gov": true, "grainger": true,
+	"graphics": true, "gratis": true, "green": true, "gripe": true,
+	"grocery": true, "group": true, "guardian": true, "gucci": true,
+	"guge": true, "guide": true, "guitars": true, "guru": true,
+	"hair": true, "hamburg": true, "hangout": true, "haus": true,
+	"hbo": true, "hdfc": true, "hdfcbank": true, "health": true,
+	"healthcare": true, "help": true, "helsinki": true, "here": true,
+	"hermes": true, "hgtv": true, "hiphop": true, "hisamitsu": true,
+	"hitachi": true, "hiv": true, "hkt": true, "hockey": true,
+	"holdings": true, "holiday": true, "homedepot": true, "homegoods": true,
+	"homes": true, "homesense": true, "honda": true, "horse": true,
+	"hospital": true, "host": true, "hosting": true, "hot": true,
+	"hoteles": true, "hotels": true, "hotmail": true, "house": true,
+	"how": true, "hsbc": true, "hughes": true, "hyatt": true,
+	"hyundai": true, "ibm": true, "icbc": true, "ice": true, "icu": true,
+	"ieee": true, "ifm": true, "ikano": true, "imamat": true, "imdb": true,
+	"immo": true, "immobilien": true, "inc": true, "industries": true,
+	"infiniti": true, "info": true, "ing": true, "ink": true,
+	"institute": true, "insurance": true, "insure": true, "int": true,
+	"international": true, "intuit": true, "investments": true,
+	"ipiranga": true, "irish": true, "ismaili": true, "ist": true,
+	"istanbul": true, "itau": true, "itv": true, "jaguar": true,
+	"java": true, "jcb": true, "jeep": true, "jetzt": true, "jewelry": true,
+	"jio": true, "jll": true, "jmp": true, "jnj": true, "jobs": true,
+	"joburg": true, "jot": true, "joy": true, "jpmorgan": true,
+	"jprs": true, "juegos": true, "juniper": true, "kaufen": true,
+	"kddi": true, "kerryhotels": true, "kerrylogistics": true,
+	"kerryproperties": true, "kfh": true, "kia": true, "kids": true,
+	"kim": true, "kinder": true, "kindle": true, "kitchen": true,
+	"kiwi": true, "koeln": true, "komatsu": true, "kosher": true,
+	"kpmg": true, "kpn": true, "krd": true, "kred": true, "kuokgroup": true,
+	"kyoto": true, "lacaixa": true, "lamborghini": true, "lamer": true,
+	"lancaster": true, "lancia": true, "land": true, "landrover": true,
+	"lanxess": true, "lasalle": true, "lat": true, "latino": true,
+	"latrobe": true, "law": true, "lawyer": true, "lds": true,
+	"lease": true, "leclerc": true, "lefrak": true, "legal": true,
+	"lego": true, "lexus": true, "lgbt": true, "lidl": true, "life": true,
+	"lifeinsurance": true, "lifestyle": true, "lighting": true,
+	"like": true, "lilly": true, "limited": true, "limo": true,
+	"lincoln": true, "linde": true, "link": true, "lipsy": true,
+	"live": true, "living": true, "llc": true, "llp": true, "loan": true,
+	"loans": true, "locker": true, "locus": true, "lol": true,
+	"london": true, "lotte": true, "lotto": true, "love": true, "lpl": true,
+	"lplfinancial": true, "ltd": true, "ltda": true, "lundbeck": true,
+	"luxe": true, "luxury": true, "macys": true, "madrid": true,
+	"maif": true, "maison": true, "makeup": true, "man": true,
+	"management": true, "mango": true, "map": true, "market": true,
+	"marketing": true, "markets": true, "marriott": true, "marshalls": true,
+	"maserati": true, "mattel": true, "mba": true, "mckinsey": true,
+	"med": true, "media": true, "meet": true, "melbourne": true,
+	"meme": true, "memorial": true, "men": true, "menu": true,
+	"merckmsd": true, "miami": true, "microsoft": true, "mil": true,
+	"mini": true, "mint": true, "mit": true, "mitsubishi": true,
+	"mlb": true, "mls": true, "mma": true, "mobi": true, "mobile": true,
+	"moda": true, "moe": true, "moi": true, "mom": true, "monash": true,
+	"money": true, "monster": true, "mormon": true, "mortgage": true,
+	"moscow": true, "moto": true, "motorcycles": true, "mov": true,
+	"movie": true, "msd": true, "mtn": true, "mtr": true, "museum": true,
+	"music": true, "mutual": true, "nab": true, "nagoya": true,
+	"name": true, "natura": true, "navy": true, "nba": true, "nec": true,
+	"net": true, "netbank": true, "netflix": true, "network": true,
+	"neustar": true, "new": true, "news": true, "next": true,
+	"nextdirect": true, "nexus": true, "nfl": true, "ngo": true,
+	"nhk": true, "nico": true, "nike": true, "nikon": true, "ninja": true,
+	"nissan": true, "nissay": true, "nokia": true,
+	"northwesternmutual": true, "norton": true, "now": true, "nowruz": true,
+	"nowtv": true, "nra": true, "nrw": true, "ntt": true, "nyc": true,
+	"obi": true, "observer": true, "office": true, "okinawa": true,
+	"olayan": true, "olayangroup": true, "oldnavy": true, "ollo": true,
+	"omega": true, "one": true, "ong": true, "onion": true, "onl": true,
+	"online": true, "ooo": true, "open": true, "oracle": true,
+	"orange": true, "org": true, "organic": true, "origins": true,
+	"osaka": true, "otsuka": true, "ott": true, "ovh": true, "page": true,
+	"panasonic": true, "paris": true, "pars": true, "partners": true,
+	"parts": true, "party": true, "passagens": true, "pay": true,
+	"pccw": true, "pet": true, "pfizer": true, "pharmacy": true,
+	"phd": true, "philips": true, "phone": true, "photo": true,
+	"photography": true, "photos": true, "physio": true, "pics": true,
+	"pictet": true, "pictures": true, "pid": true, "pin": true,
+	"ping": true, "pink": true, "pioneer": true, "pizza": true,
+	"place": true, "play": true, "playstation": true, "plumbing": true,
+	"plus": true, "pnc": true, "pohl": true, "poker": true, "politie": true,
+	"porn": true, "post": true, "pramerica": true, "praxi": true,
+	"press": true, "prime": true, "pro": true, "prod": true,
+	"productions": true, "prof": true, "progressive": true, "promo": true,
+	"properties": true, "property": true, "protection": true, "pru": true,
+	"prudential": true, "pub": true, "pwc": true, "qpon": true,
+	"quebec": true, "quest": true, "racing": true, "radio": true,
+	"read": true, "realestate": true, "realtor": true, "realty": true,
+	"recipes": true, "red": true, "redstone": true, "redumbrella": true,
+	"rehab": true, "reise": true, "reisen": true, "reit": true,
+	"reliance": true, "ren": true, "rent": true, "rentals": true,
+	"repair": true, "report": true, "republican": true, "rest": true,
+	"restaurant": true, "review": true, "reviews": true, "rexroth": true,
+	"rich": true, "richardli": true, "ricoh": true, "ril": true,
+	"rio": true, "rip": true, "rocher": true, "rocks": true, "rodeo": true,
+	"rogers": true, "room": true, "rsvp": true, "rugby": true, "ruhr": true,
+	"run": true, "rwe": true, "ryukyu": true, "saarland": true,
+	"safe": true, "safety": true, "sakura": true, "sale": true,
+	"salon": true, "samsclub": true, "samsung": true, "sandvik": true,
+	"sandvikcoromant": true, "sanofi": true, "sap": true, "sarl": true,
+	"sas": true, "save": true, "saxo": true, "sbi": true, "sbs": true,
+	"sca": true, "scb": true, "schaeffler": true, "schmidt": true,
+	"scholarships": true, "school": true, "schule": true, "schwarz": true,
+	"science": true, "scot": true, "search": true, "seat": true,
+	"secure": true, "security": true, "seek": true, "select": true,
+	"sener": true, "services": true, "seven": true, "sew": true,
+	"sex": true, "sexy": true, "sfr": true, "shangrila": true,
+	"sharp": true, "shaw": true, "shell": true, "shia": true,
+	"shiksha": true, "shoes": true, "shop": true, "shopping": true,
+	"shouji": true, "show": true, "showtime": true, "silk": true,
+	"sina": true, "singles": true, "site": true, "ski": true, "skin": true,
+	"sky": true, "skype": true, "sling": true, "smart": true, "smile": true,
+	"sncf": true, "soccer": true, "social": true, "softbank": true,
+	"software": true, "sohu": true, "solar": true, "solutions": true,
+	"song": true, "sony": true, "soy": true, "spa": true, "space": true,
+	"sport": true, "spot": true, "srl": true, "stada": true,
+	"staples": true, "star": true, "statebank": true, "statefarm": true,
+	"stc": true, "stcgroup": true, "stockholm": true, "storage": true,
+	"store": true, "stream": true, "studio": true, "study": true,
+	"style": true, "sucks": true, "supplies": true, "supply": true,
+	"support": true, "surf": true, "surgery": true, "suzuki": true,
+	"swatch": true, "swiss": true, "sydney": true, "systems": true,
+	"tab": true, "taipei": true, "talk": true, "taobao": true,
+	"target": true, "tatamotors": true, "tatar": true, "tattoo": true,
+	"tax": true, "taxi": true, "tci": true, "tdk": true, "team": true,
+	"tech": true, "technology": true, "tel": true, "temasek": true,
+	"tennis": true, "teva": true, "thd": true, "theater": true,
+	"theatre": true, "tiaa": true, "tickets": true, "tienda": true,
+	"tiffany": true, "tips": true, "tires": true, "tirol": true,
+	"tjmaxx": true, "tjx": true, "tkmaxx": true, "tmall": true,
+	"today": true, "tokyo": true, "tools": true, "top": true, "toray": true,
+	"toshiba": true, "total": true, "tours": true, "town": true,
+	"toyota": true, "toys": true, "trade": true, "trading": true,
+	"training": true, "travel": true, "travelchannel": true,
+	"travelers": true, "travelersinsurance": true, "trust": true,
+	"trv": true, "tube": true, "tui": true, "tunes": true, "tushu": true,
+	"tvs": true, "ubank": true, "ubs": true, "unicom": true,
+	"university": true, "uno": true, "uol": true, "ups": true,
+	"vacations": true, "vana": true, "vanguard": true, "vegas": true,
+	"ventures": true, "verisign": true, "versicherung": true, "vet": true,
+	"viajes": true, "video": true, "vig": true, "viking": true,
+	"villas": true, "vin": true, "vip": true, "virgin": true, "visa": true,
+	"vision": true, "viva": true, "vivo": true, "vlaanderen": true,
+	"vodka": true, "volkswagen": true, "volvo": true, "vote": true,
+	"voting": true, "voto": true, "voyage": true, "vuelos": true,
+	"wales": true, "walmart": true, "walter": true, "wang": true,
+	"wanggou": true, "watch": true, "watches": true, "weather": true,
+	"weatherchannel": true, "webcam": true, "weber": true, "website": true,
+	"wedding": true, "weibo": true, "weir": true, "whoswho": true,
+	"wien": true, "wiki": true, "williamhill": true, "win": true,
+	"windows": true, "wine": true, "winners": true, "wme": true,
+	"wolterskluwer": true, "woodside": true, "work": true, "works": true,
+	"world": true, "wow": true, "wtc": true, "wtf": true, "xbox": true,
+	"xerox": true, "xfinity": true, "xihuan": true, "xin": true,
+	"xxx": true, "xyz": true, "yachts": true, "yahoo": true,
+	"yamaxun": true, "yandex": true, "yodobashi": true, "yoga": true,
+	"yokohama": true, "you": true, "youtube": true, "yun": true,
+	"zappos": true, "zara": true, "zero": true, "zip": true, "zone": true,
+	"zuerich": true,
+}
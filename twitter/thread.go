@@ -0,0 +1,133 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/thejokersthief/go-twitter/twitter/text"
+)
+
+// ThreadError is returned by StatusService.PostThread when a part of a thread
+// fails to post. Pass the Remaining parts to PostThread with the
+// InReplyToStatusID to resume the thread.
+type ThreadError struct {
+	// Posted are the Tweets posted before the failure, in order.
+	Posted []Tweet
+	// Remaining are the parts not posted, starting with the failed part.
+	Remaining []string
+	// InReplyToStatusID is the ID of the Tweet the first Remaining part
+	// replies to.
+	InReplyToStatusID int64
+	// Err is the error posting the first Remaining part.
+	Err error
+}
+
+func (e *ThreadError) Error() string {
+	return fmt.Sprintf("twitter: thread stopped after %d of %d parts: %v", len(e.Posted), len(e.Posted)+len(e.Remaining), e.Err)
+}
+
+// PostThread posts the parts as a thread, each part replying to the
+// previous part. The params apply to each part, except MediaIds, which only
+// apply to the first part, and InReplyToStatusID, which the first part
+// replies to. Parts are checked with the text package before any are posted.
+// If a part fails to post, the posted Tweets and a *ThreadError are returned.
+// Requires a user auth context.
+func (s *StatusService) PostThread(parts []string, params *StatusUpdateParams) ([]Tweet, *http.Response, error) {
+	if params == nil {
+		params = &StatusUpdateParams{}
+	}
+	for i, part := range parts {
+		if !text.IsValid(part) {
+			return nil, nil, fmt.Errorf("twitter: thread part %d is not valid Tweet text", i+1)
+		}
+	}
+	tweets := make([]Tweet, 0, len(parts))
+	replyTo := params.InReplyToStatusID
+	var resp *http.Response
+	for i, part := range parts {
+		partParams := *params
+		partParams.InReplyToStatusID = replyTo
+		if i > 0 {
+			partParams.MediaIds = nil
+		}
+		var tweet *Tweet
+		var err error
+		tweet, resp, err = s.Update(part, &partParams)
+		if err != nil {
+			return tweets, resp, &ThreadError{
+				Posted:            tweets,
+				Remaining:         parts[i:],
+				InReplyToStatusID: replyTo,
+				Err:               err,
+			}
+		}
+		tweets = append(tweets, *tweet)
+		replyTo = tweet.ID
+	}
+	return tweets, resp, nil
+}
+
+// SplitThread splits long text into parts which each fit in a Tweet, as
+// counted by the text package. Text is split at the end of a sentence or
+// line if one is in the second half of a part, otherwise between words. If
+// numbered is true and there is more than one part, parts end with their
+// number, e.g. "1/5".
+func SplitThread(longText string, numbered bool) []string {
+	if !numbered {
+		return splitThread(longText, 0)
+	}
+	for digits := 1; ; digits++ {
+		// reserve room for " n/n"
+		parts := splitThread(longText, 2*digits+2)
+		if len(parts) == 1 {
+			return splitThread(longText, 0)
+		}
+		if len(strconv.Itoa(len(parts))) <= digits {
+			for i := range parts {
+				parts[i] = fmt.Sprintf("%s %d/%d", parts[i], i+1, len(parts))
+			}
+			return parts
+		}
+	}
+}
+
+// splitThread splits text into parts which fit in a Tweet with reserve
+// characters to spare.
+func splitThread(longText string, reserve int) []string {
+	config := text.V3Config
+	config.MaxWeightedTweetLength -= reserve
+	var parts []string
+	runes := []rune(strings.TrimSpace(longText))
+	for len(runes) > 0 {
+		results := config.Parse(string(runes))
+		end := len(runes)
+		if results.WeightedLength > config.MaxWeightedTweetLength {
+			end = splitPoint(runes, results.ValidRange.End)
+		}
+		parts = append(parts, strings.TrimRightFunc(string(runes[:end]), unicode.IsSpace))
+		runes = []rune(strings.TrimLeftFunc(string(runes[end:]), unicode.IsSpace))
+	}
+	return parts
+}
+
+// splitPoint returns the offset at which to split runes which fit up to end.
+func splitPoint(runes []rune, end int) int {
+	for i := end; i > end/2; i-- {
+		if unicode.IsSpace(runes[i]) && (runes[i] == '\n' || strings.ContainsRune(".!?", runes[i-1])) {
+			return i
+		}
+	}
+	for i := end; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	if end == 0 {
+		// always make progress
+		return 1
+	}
+	return end
+}
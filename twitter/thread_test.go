@@ -0,0 +1,119 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thejokersthief/go-twitter/twitter/text"
+)
+
+func TestSplitThread(t *testing.T) {
+	assert.Equal(t, []string{"short text"}, SplitThread("  short text\n", false))
+	assert.Equal(t, []string{"short text"}, SplitThread("short text", true))
+
+	first := strings.Repeat("a", 150) + "."
+	second := strings.Repeat("b", 100) + "."
+	third := strings.Repeat("c", 100) + "!"
+	longText := first + " " + second + " " + third
+	// splits at the last sentence which fits
+	assert.Equal(t, []string{first + " " + second, third}, SplitThread(longText, false))
+	assert.Equal(t, []string{first + " " + second + " 1/2", third + " 2/2"}, SplitThread(longText, true))
+}
+
+func TestSplitThread_words(t *testing.T) {
+	// 50 words of 9 characters, a URL, and 120 CJK characters
+	longText := strings.Repeat("wordwords ", 50) + "https://golang.org/doc/ " + strings.Repeat("日本語", 40)
+	parts := SplitThread(longText, false)
+	assert.Len(t, parts, 3)
+	assert.Equal(t, strings.TrimSpace(strings.Repeat("wordwords ", 28)), parts[0])
+	for _, part := range parts {
+		assert.True(t, text.IsValid(part))
+	}
+	assert.Equal(t, strings.Join(strings.Fields(longText), ""), strings.Join(strings.Fields(strings.Join(parts, "")), ""))
+}
+
+func TestSplitThread_numberDigits(t *testing.T) {
+	// 12 parts need room for " 12/12"
+	longText := strings.Repeat(strings.Repeat("a", 274)+" ", 12)
+	parts := SplitThread(longText, true)
+	assert.Len(t, parts, 12)
+	assert.Equal(t, strings.Repeat("a", 274)+" 1/12", parts[0])
+	assert.Equal(t, strings.Repeat("a", 274)+" 12/12", parts[11])
+}
+
+// threadServer handles status updates, failing the update with status fail,
+// and records the status and in_reply_to_status_id of each update.
+func threadServer(t *testing.T, mux *http.ServeMux, fail string) *[][2]string {
+	var updates [][2]string
+	mux.HandleFunc("/1.1/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		r.ParseForm()
+		status := r.Form.Get("status")
+		updates = append(updates, [2]string{status, r.Form.Get("in_reply_to_status_id")})
+		w.Header().Set("Content-Type", "application/json")
+		if status == fail {
+			w.WriteHeader(403)
+			fmt.Fprintf(w, `{"errors": [{"message": "Status is a duplicate", "code": 187}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %d, "text": %q}`, 100+len(updates), status)
+	})
+	return &updates
+}
+
+func TestStatusService_PostThread(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	updates := threadServer(t, mux, "")
+
+	client := NewClient(httpClient)
+	params := &StatusUpdateParams{InReplyToStatusID: 5, MediaIds: []int64{7}}
+	tweets, _, err := client.Statuses.PostThread([]string{"one", "two", "three"}, params)
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]string{{"one", "5"}, {"two", "101"}, {"three", "102"}}, *updates)
+	if assert.Len(t, tweets, 3) {
+		assert.Equal(t, []int64{101, 102, 103}, []int64{tweets[0].ID, tweets[1].ID, tweets[2].ID})
+	}
+	// params are not modified
+	assert.Equal(t, &StatusUpdateParams{InReplyToStatusID: 5, MediaIds: []int64{7}}, params)
+}
+
+func TestStatusService_PostThreadResume(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	updates := threadServer(t, mux, "two")
+
+	client := NewClient(httpClient)
+	tweets, resp, err := client.Statuses.PostThread([]string{"one", "two", "three"}, nil)
+	assert.Equal(t, 403, resp.StatusCode)
+	threadErr, ok := err.(*ThreadError)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, []string{"two", "three"}, threadErr.Remaining)
+	assert.Equal(t, int64(101), threadErr.InReplyToStatusID)
+	assert.Equal(t, tweets, threadErr.Posted)
+	assert.Len(t, tweets, 1)
+	assert.Equal(t, "twitter: thread stopped after 1 of 3 parts: twitter: 187 Status is a duplicate", err.Error())
+
+	// resume, retrying the failed part with new text
+	remaining := []string{"two again", threadErr.Remaining[1]}
+	tweets, _, err = client.Statuses.PostThread(remaining, &StatusUpdateParams{InReplyToStatusID: threadErr.InReplyToStatusID})
+	assert.Nil(t, err)
+	assert.Len(t, tweets, 2)
+	assert.Equal(t, [][2]string{{"one", ""}, {"two", "101"}, {"two again", "101"}, {"three", "103"}}, *updates)
+}
+
+func TestStatusService_PostThreadInvalidPart(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	updates := threadServer(t, mux, "")
+
+	client := NewClient(httpClient)
+	_, _, err := client.Statuses.PostThread([]string{"one", strings.Repeat("a", 281)}, nil)
+	assert.EqualError(t, err, "twitter: thread part 2 is not valid Tweet text")
+	assert.Empty(t, *updates)
+}
@@ -30,6 +30,7 @@ type UserTimelineParams struct {
 	ExcludeReplies     *bool  `url:"exclude_replies,omitempty"`
 	ContributorDetails *bool  `url:"contributor_details,omitempty"`
 	IncludeRetweets    *bool  `url:"include_rts,omitempty"`
+	TweetMode          string `url:"tweet_mode,omitempty"`
 }
 
 // UserTimeline returns recent Tweets from the specified user.
@@ -43,13 +44,14 @@ func (s *TimelineService) UserTimeline(params *UserTimelineParams) ([]Tweet, *ht
 
 // HomeTimelineParams are the parameters for TimelineService.HomeTimeline.
 type HomeTimelineParams struct {
-	Count              int   `url:"count,omitempty"`
-	SinceID            int64 `url:"since_id,omitempty"`
-	MaxID              int64 `url:"max_id,omitempty"`
-	TrimUser           *bool `url:"trim_user,omitempty"`
-	ExcludeReplies     *bool `url:"exclude_replies,omitempty"`
-	ContributorDetails *bool `url:"contributor_details,omitempty"`
-	IncludeEntities    *bool `url:"include_entities,omitempty"`
+	Count              int    `url:"count,omitempty"`
+	SinceID            int64  `url:"since_id,omitempty"`
+	MaxID              int64  `url:"max_id,omitempty"`
+	TrimUser           *bool  `url:"trim_user,omitempty"`
+	ExcludeReplies     *bool  `url:"exclude_replies,omitempty"`
+	ContributorDetails *bool  `url:"contributor_details,omitempty"`
+	IncludeEntities    *bool  `url:"include_entities,omitempty"`
+	TweetMode          string `url:"tweet_mode,omitempty"`
 }
 
 // HomeTimeline returns recent Tweets and retweets from the user and those
@@ -65,12 +67,13 @@ func (s *TimelineService) HomeTimeline(params *HomeTimelineParams) ([]Tweet, *ht
 
 // MentionTimelineParams are the parameters for TimelineService.MentionTimeline.
 type MentionTimelineParams struct {
-	Count              int   `url:"count,omitempty"`
-	SinceID            int64 `url:"since_id,omitempty"`
-	MaxID              int64 `url:"max_id,omitempty"`
-	TrimUser           *bool `url:"trim_user,omitempty"`
-	ContributorDetails *bool `url:"contributor_details,omitempty"`
-	IncludeEntities    *bool `url:"include_entities,omitempty"`
+	Count              int    `url:"count,omitempty"`
+	SinceID            int64  `url:"since_id,omitempty"`
+	MaxID              int64  `url:"max_id,omitempty"`
+	TrimUser           *bool  `url:"trim_user,omitempty"`
+	ContributorDetails *bool  `url:"contributor_details,omitempty"`
+	IncludeEntities    *bool  `url:"include_entities,omitempty"`
+	TweetMode          string `url:"tweet_mode,omitempty"`
 }
 
 // MentionTimeline returns recent Tweet mentions of the authenticated user.
@@ -86,12 +89,13 @@ func (s *TimelineService) MentionTimeline(params *MentionTimelineParams) ([]Twee
 // RetweetsOfMeTimelineParams are the parameters for
 // TimelineService.RetweetsOfMeTimeline.
 type RetweetsOfMeTimelineParams struct {
-	Count               int   `url:"count,omitempty"`
-	SinceID             int64 `url:"since_id,omitempty"`
-	MaxID               int64 `url:"max_id,omitempty"`
-	TrimUser            *bool `url:"trim_user,omitempty"`
-	IncludeEntities     *bool `url:"include_entities,omitempty"`
-	IncludeUserEntities *bool `url:"include_user_entities"`
+	Count               int    `url:"count,omitempty"`
+	SinceID             int64  `url:"since_id,omitempty"`
+	MaxID               int64  `url:"max_id,omitempty"`
+	TrimUser            *bool  `url:"trim_user,omitempty"`
+	IncludeEntities     *bool  `url:"include_entities,omitempty"`
+	IncludeUserEntities *bool  `url:"include_user_entities"`
+	TweetMode           string `url:"tweet_mode,omitempty"`
 }
 
 // RetweetsOfMeTimeline returns the most recent Tweets by the authenticated
@@ -104,3 +108,112 @@ func (s *TimelineService) RetweetsOfMeTimeline(params *RetweetsOfMeTimelineParam
 	resp, err := s.sling.New().Get("retweets_of_me.json").QueryStruct(params).Receive(tweets, apiError)
 	return *tweets, resp, relevantError(err, *apiError)
 }
+
+// TimelineWalker walks the Tweets of a timeline which are newer than the
+// Tweet ID saved in a Checkpoint. Tweets are handled oldest first and the
+// Checkpoint is saved after each, so a restarted walk resumes without
+// handling Tweets twice.
+type TimelineWalker struct {
+	fetch      func(sinceID, maxID int64) ([]Tweet, error)
+	checkpoint Checkpoint
+	key        string
+}
+
+// newTimelineWalker returns a TimelineWalker which fetches pages of a
+// timeline with the given fetch func, checkpointed under the given key.
+func newTimelineWalker(checkpoint Checkpoint, key string, fetch func(sinceID, maxID int64) ([]Tweet, *http.Response, error)) *TimelineWalker {
+	return &TimelineWalker{
+		fetch: func(sinceID, maxID int64) ([]Tweet, error) {
+			tweets, _, err := fetch(sinceID, maxID)
+			return tweets, err
+		},
+		checkpoint: checkpoint,
+		key:        key,
+	}
+}
+
+// UserTimelineWalker returns a TimelineWalker for the user timeline with the
+// given params, checkpointed under the given key. The params SinceID and
+// MaxID are set by the walker.
+func (s *TimelineService) UserTimelineWalker(params *UserTimelineParams, checkpoint Checkpoint, key string) *TimelineWalker {
+	p := UserTimelineParams{}
+	if params != nil {
+		p = *params
+	}
+	return newTimelineWalker(checkpoint, key, func(sinceID, maxID int64) ([]Tweet, *http.Response, error) {
+		p.SinceID, p.MaxID = sinceID, maxID
+		return s.UserTimeline(&p)
+	})
+}
+
+// HomeTimelineWalker returns a TimelineWalker for the home timeline with the
+// given params, checkpointed under the given key. The params SinceID and
+// MaxID are set by the walker.
+// Requires a user auth context.
+func (s *TimelineService) HomeTimelineWalker(params *HomeTimelineParams, checkpoint Checkpoint, key string) *TimelineWalker {
+	p := HomeTimelineParams{}
+	if params != nil {
+		p = *params
+	}
+	return newTimelineWalker(checkpoint, key, func(sinceID, maxID int64) ([]Tweet, *http.Response, error) {
+		p.SinceID, p.MaxID = sinceID, maxID
+		return s.HomeTimeline(&p)
+	})
+}
+
+// MentionTimelineWalker returns a TimelineWalker for the mention timeline
+// with the given params, checkpointed under the given key. The params SinceID
+// and MaxID are set by the walker.
+// Requires a user auth context.
+func (s *TimelineService) MentionTimelineWalker(params *MentionTimelineParams, checkpoint Checkpoint, key string) *TimelineWalker {
+	p := MentionTimelineParams{}
+	if params != nil {
+		p = *params
+	}
+	return newTimelineWalker(checkpoint, key, func(sinceID, maxID int64) ([]Tweet, *http.Response, error) {
+		p.SinceID, p.MaxID = sinceID, maxID
+		return s.MentionTimeline(&p)
+	})
+}
+
+// Walk fetches the Tweets newer than the checkpointed Tweet ID, then calls
+// handle with each Tweet, oldest first, saving its ID to the Checkpoint once
+// handled. Walk stops at the first error from fetching, handle, or saving.
+// Without a checkpointed ID, the whole timeline available is walked.
+func (w *TimelineWalker) Walk(handle func(tweet *Tweet) error) error {
+	sinceID, err := w.checkpoint.Load(w.key)
+	if err != nil {
+		return err
+	}
+	var tweets []Tweet
+	var maxID int64
+	for {
+		page, err := w.fetch(sinceID, maxID)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, tweet := range page {
+			if tweet.ID > sinceID {
+				tweets = append(tweets, tweet)
+			}
+		}
+		// timelines are newest first, page back with max_id until caught up
+		next := page[len(page)-1].ID - 1
+		if next <= sinceID || (maxID != 0 && next >= maxID) {
+			break
+		}
+		maxID = next
+	}
+	for i := len(tweets) - 1; i >= 0; i-- {
+		if err := handle(&tweets[i]); err != nil {
+			return err
+		}
+		if err := w.checkpoint.Save(w.key, tweets[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package twitter
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -79,3 +80,61 @@ func TestTimelineService_RetweetsOfMeTimeline(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, tweets)
 }
+
+func TestTimelineWalker_Walk(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/statuses/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("max_id") {
+		case "":
+			assertQuery(t, map[string]string{"screen_name": "golang", "since_id": "20"}, r)
+			fmt.Fprintf(w, `[{"id": 25}, {"id": 24}]`)
+		case "23":
+			fmt.Fprintf(w, `[{"id": 23}, {"id": 22}]`)
+		case "21":
+			fmt.Fprintf(w, `[]`)
+		default:
+			t.Errorf("unexpected max_id %s", r.URL.Query().Get("max_id"))
+		}
+	})
+
+	client := NewClient(httpClient)
+	checkpoint := NewMemoryCheckpoint()
+	checkpoint.Save("golang", 20)
+	walker := client.Timelines.UserTimelineWalker(&UserTimelineParams{ScreenName: "golang"}, checkpoint, "golang")
+	var ids []int64
+	err := walker.Walk(func(tweet *Tweet) error {
+		ids = append(ids, tweet.ID)
+		if tweet.ID == 23 {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "handler failed")
+	assert.Equal(t, []int64{22, 23}, ids)
+	// the failed Tweet is not checkpointed
+	id, _ := checkpoint.Load("golang")
+	assert.Equal(t, int64(22), id)
+}
+
+func TestTimelineWalker_WalkError(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/1.1/statuses/home_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, `{"errors": [{"message": "Rate limit exceeded", "code": 88}]}`)
+	})
+
+	client := NewClient(httpClient)
+	walker := client.Timelines.HomeTimelineWalker(nil, NewMemoryCheckpoint(), "home")
+	err := walker.Walk(func(tweet *Tweet) error {
+		t.Errorf("unexpected Tweet %d", tweet.ID)
+		return nil
+	})
+	assert.EqualError(t, err, "twitter: 88 Rate limit exceeded")
+}
@@ -24,25 +24,28 @@ type Client struct {
 	Friendships    *FriendshipService
 	Search         *SearchService
 	Block          *BlockService
+	Lists          *ListService
 }
 
 // NewClient returns a new Client.
 func NewClient(httpClient *http.Client) *Client {
 	base := sling.New().Client(httpClient).Base(twitterAPI)
+	search := newSearchService(base.New())
 	return &Client{
 		sling:          base,
 		Accounts:       newAccountService(base.New()),
-		Statuses:       newStatusService(base.New()),
+		Statuses:       newStatusService(base.New(), search),
 		Timelines:      newTimelineService(base.New()),
 		Users:          newUserService(base.New()),
 		Favorites:      newFavoriteService(base.New()),
 		Followers:      newFollowerService(base.New()),
 		Friends:        newFriendService(base.New()),
 		DirectMessages: newDirectMessageService(base.New()),
-		Streams:        newStreamService(httpClient, base.New()),
+		Streams:        newStreamService(httpClient, base.New(), search),
 		Friendships:    newFriendshipService(base.New()),
-		Search:         newSearchService(base.New()),
+		Search:         search,
 		Block:          newBlockService(base.New()),
+		Lists:          newListService(base.New()),
 	}
 }
 
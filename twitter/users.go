@@ -1,14 +1,21 @@
 package twitter
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/dghubble/sling"
 )
 
-// User represents a Twitter User.
+// User represents a Twitter User. Blocking, BlockedBy, Following, and Muting
+// describe the authenticating user's relationship to the User, and are only
+// set by some endpoints.
 // https://dev.twitter.com/overview/api/users
 type User struct {
+	BlockedBy                      bool          `json:"blocked_by"`
+	Blocking                       bool          `json:"blocking"`
 	ContributorsEnabled            bool          `json:"contributors_enabled"`
 	CreatedAt                      string        `json:"created_at"`
 	DefaultProfile                 bool          `json:"default_profile"`
@@ -22,14 +29,20 @@ type User struct {
 	FollowersCount                 int           `json:"followers_count"`
 	FriendsCount                   int           `json:"friends_count"`
 	GeoEnabled                     bool          `json:"geo_enabled"`
+	HasExtendedProfile             bool          `json:"has_extended_profile"`
 	ID                             int64         `json:"id"`
 	IDStr                          string        `json:"id_str"`
-	IsTranslator                   bool          `json:"id_translator"`
+	IsTranslationEnabled           bool          `json:"is_translation_enabled"`
+	IsTranslator                   bool          `json:"is_translator"`
 	Lang                           string        `json:"lang"`
 	ListedCount                    int           `json:"listed_count"`
 	Location                       string        `json:"location"`
+	Muting                         bool          `json:"muting"`
 	Name                           string        `json:"name"`
+	NeedsPhoneVerification         bool          `json:"needs_phone_verification"`
 	Notifications                  bool          `json:"notifications"`
+	PinnedTweetIDs                 []int64       `json:"pinned_tweet_ids"`
+	PinnedTweetIDsStr              []string      `json:"pinned_tweet_ids_str"`
 	ProfileBackgroundColor         string        `json:"profile_background_color"`
 	ProfileBackgroundImageURL      string        `json:"profile_background_image_url"`
 	ProfileBackgroundImageURLHttps string        `json:"profile_background_image_url_https"`
@@ -47,12 +60,45 @@ type User struct {
 	ShowAllInlineMedia             bool          `json:"show_all_inline_media"`
 	Status                         *Tweet        `json:"status"`
 	StatusesCount                  int           `json:"statuses_count"`
+	Suspended                      bool          `json:"suspended"`
 	Timezone                       string        `json:"time_zone"`
+	TranslatorType                 string        `json:"translator_type"`
 	URL                            string        `json:"url"`
 	UtcOffset                      int           `json:"utc_offset"`
 	Verified                       bool          `json:"verified"`
-	WithheldInCountries            string        `json:"withheld_in_countries"`
+	WithheldInCountries            []string      `json:"withheld_in_countries"`
 	WithholdScope                  string        `json:"withheld_scope"`
+	// Extra are the JSON fields of the User which are not decoded into
+	// other fields. They are encoded with the User, so it round-trips.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CreatedAtTime returns the time the User account was created.
+func (u User) CreatedAtTime() (time.Time, error) {
+	return time.Parse(time.RubyDate, u.CreatedAt)
+}
+
+// Profile banner sizes for ProfileBannerURLSize.
+// https://dev.twitter.com/overview/general/user-profile-images-and-banners
+const (
+	ProfileBannerWeb          = "web"
+	ProfileBannerWebRetina    = "web_retina"
+	ProfileBannerIPad         = "ipad"
+	ProfileBannerIPadRetina   = "ipad_retina"
+	ProfileBannerMobile       = "mobile"
+	ProfileBannerMobileRetina = "mobile_retina"
+	ProfileBanner300x100      = "300x100"
+	ProfileBanner600x200      = "600x200"
+	ProfileBanner1500x500     = "1500x500"
+)
+
+// ProfileBannerURLSize returns the URL of the User's profile banner in the
+// given size, or "" if the User has no profile banner.
+func (u User) ProfileBannerURLSize(size string) string {
+	if u.ProfileBannerURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(u.ProfileBannerURL, "/") + "/" + size
 }
 
 // UserService provides methods for accessing Twitter user API endpoints.
@@ -1,9 +1,11 @@
 package twitter
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -90,3 +92,91 @@ func TestUserService_SearchHandlesNilParams(t *testing.T) {
 	client := NewClient(httpClient)
 	client.Users.Search("news", nil)
 }
+
+func TestUser_CreatedAtTime(t *testing.T) {
+	user := User{CreatedAt: "Wed Jan 13 19:10:28 +0000 2010"}
+	createdAt, err := user.CreatedAtTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2010, 1, 13, 19, 10, 28, 0, time.UTC), createdAt.UTC())
+}
+
+func TestUser_decode(t *testing.T) {
+	cases := []struct {
+		description string
+		data        string
+		expected    *User
+	}{
+		{
+			"verified user",
+			`{"id":783214,"id_str":"783214","name":"Twitter","screen_name":"Twitter","location":"everywhere","url":"https:\/\/t.co\/TAXQpsHa5X","description":"What's happening?!","protected":false,"verified":true,"followers_count":56720475,"friends_count":12,"listed_count":90389,"created_at":"Tue Feb 20 14:35:54 +0000 2007","favourites_count":5985,"utc_offset":null,"time_zone":null,"geo_enabled":true,"statuses_count":11428,"lang":null,"contributors_enabled":false,"is_translator":false,"is_translation_enabled":false,"profile_background_color":"ACDED6","profile_background_tile":true,"profile_banner_url":"https:\/\/pbs.twimg.com\/profile_banners\/783214\/1556918042","profile_use_background_image":true,"has_extended_profile":true,"default_profile":false,"default_profile_image":false,"pinned_tweet_ids":[1111111111111111111],"pinned_tweet_ids_str":["1111111111111111111"],"following":false,"follow_request_sent":false,"notifications":false,"translator_type":"regular","withheld_in_countries":[]}`,
+			&User{
+				ID:                        783214,
+				IDStr:                     "783214",
+				Name:                      "Twitter",
+				ScreenName:                "Twitter",
+				Location:                  "everywhere",
+				URL:                       "https://t.co/TAXQpsHa5X",
+				Description:               "What's happening?!",
+				Verified:                  true,
+				FollowersCount:            56720475,
+				FriendsCount:              12,
+				ListedCount:               90389,
+				CreatedAt:                 "Tue Feb 20 14:35:54 +0000 2007",
+				FavouritesCount:           5985,
+				GeoEnabled:                true,
+				StatusesCount:             11428,
+				ProfileBackgroundColor:    "ACDED6",
+				ProfileBackgroundTile:     true,
+				ProfileBannerURL:          "https://pbs.twimg.com/profile_banners/783214/1556918042",
+				ProfileUseBackgroundImage: true,
+				HasExtendedProfile:        true,
+				PinnedTweetIDs:            []int64{1111111111111111111},
+				PinnedTweetIDsStr:         []string{"1111111111111111111"},
+				TranslatorType:            "regular",
+				WithheldInCountries:       []string{},
+			},
+		},
+		{
+			"protected user, from the authenticating user's perspective",
+			`{"id":12345,"id_str":"12345","name":"Private","screen_name":"private","protected":true,"verified":false,"followers_count":3,"friends_count":4,"is_translator":true,"translator_type":"badged","following":false,"follow_request_sent":true,"muting":true,"blocking":false,"blocked_by":true,"needs_phone_verification":true}`,
+			&User{
+				ID:                     12345,
+				IDStr:                  "12345",
+				Name:                   "Private",
+				ScreenName:             "private",
+				Protected:              true,
+				FollowersCount:         3,
+				FriendsCount:           4,
+				IsTranslator:           true,
+				TranslatorType:         "badged",
+				FollowRequestSent:      true,
+				Muting:                 true,
+				BlockedBy:              true,
+				NeedsPhoneVerification: true,
+			},
+		},
+		{
+			"suspended user",
+			`{"id":67890,"id_str":"67890","name":"Suspended","screen_name":"suspended","suspended":true,"needs_phone_verification":false,"statuses_count":0}`,
+			&User{ID: 67890, IDStr: "67890", Name: "Suspended", ScreenName: "suspended", Suspended: true},
+		},
+		{
+			"withheld user",
+			`{"id":24680,"id_str":"24680","name":"Withheld","screen_name":"withheld","withheld_in_countries":["DE","FR"],"withheld_scope":"user"}`,
+			&User{ID: 24680, IDStr: "24680", Name: "Withheld", ScreenName: "withheld", WithheldInCountries: []string{"DE", "FR"}, WithholdScope: "user"},
+		},
+	}
+	for _, c := range cases {
+		user := new(User)
+		err := json.Unmarshal([]byte(c.data), user)
+		assert.Nil(t, err, c.description)
+		assert.Equal(t, c.expected, user, c.description)
+	}
+}
+
+func TestUser_ProfileBannerURLSize(t *testing.T) {
+	user := User{ProfileBannerURL: "https://pbs.twimg.com/profile_banners/783214/1556918042"}
+	assert.Equal(t, "https://pbs.twimg.com/profile_banners/783214/1556918042/1500x500", user.ProfileBannerURLSize(ProfileBanner1500x500))
+	assert.Equal(t, "https://pbs.twimg.com/profile_banners/783214/1556918042/mobile_retina", user.ProfileBannerURLSize(ProfileBannerMobileRetina))
+	assert.Equal(t, "", User{}.ProfileBannerURLSize(ProfileBannerWeb))
+}